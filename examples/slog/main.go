@@ -11,7 +11,7 @@ import (
 
 func init() {
 	std := slog.Default()
-	std.SetOutput(slog.OutputTerminal, os.Stdout)
+	std.SetOutput(slog.OutputAuto, os.Stdout)
 	std.SetLevel(slog.LevelVeryVerbose)
 	std.SetFlags(slog.FlagUTC)
 	if _, file, _, ok := runtime.Caller(0); ok {
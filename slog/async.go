@@ -0,0 +1,226 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// AsyncPolicy specifies what happens when the asynchronous queue is full.
+type AsyncPolicy int
+
+const (
+	// AsyncBlock blocks the caller until there is room in the queue.
+	AsyncBlock AsyncPolicy = iota
+	// AsyncDropNewest drops the message being logged.
+	AsyncDropNewest
+	// AsyncDropBelow drops messages less severe than the threshold level and blocks for the others.
+	AsyncDropBelow
+)
+
+type asyncItem struct {
+	m       *message
+	flushed chan<- struct{}
+}
+
+type asyncQueue struct {
+	c      *core
+	policy AsyncPolicy
+	level  Level
+
+	mu     sync.RWMutex
+	closed bool
+	ch     chan asyncItem
+	done   chan struct{}
+
+	errMu sync.Mutex
+	err   error
+}
+
+func newAsyncQueue(c *core, size int, policy AsyncPolicy, level Level) *asyncQueue {
+	q := &asyncQueue{
+		c:      c,
+		policy: policy,
+		level:  level,
+		ch:     make(chan asyncItem, size),
+		done:   make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+	for item := range q.ch {
+		if item.flushed != nil {
+			close(item.flushed)
+			continue
+		}
+		if err := q.c.writeMsg(item.m); err != nil {
+			q.errMu.Lock()
+			if q.err == nil {
+				q.err = err
+			}
+			q.errMu.Unlock()
+		}
+	}
+}
+
+// snapshot copies the message text and renders the extra payload so that the message can be written later.
+func snapshot(m *message) *message {
+	s := *m
+	s.text = append([]byte(nil), m.text...)
+	if m.writeExtra != nil {
+		var extra bytes.Buffer
+		extraErr := m.writeExtra(&extra)
+		s.writeExtra = func(w io.Writer) error {
+			if _, err := w.Write(extra.Bytes()); err != nil {
+				return err
+			}
+			return extraErr
+		}
+	}
+	if m.binary != nil {
+		s.binary = &binaryDump{append([]byte(nil), m.binary.data...), m.binary.wrap, append([]bool(nil), m.binary.mask...)}
+	}
+	s.attrs = append([]Attr(nil), m.attrs...)
+	return &s
+}
+
+// enqueue returns false if the queue is closed and the message should be written synchronously.
+func (q *asyncQueue) enqueue(m *message) bool {
+	item := asyncItem{m: snapshot(m)}
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return false
+	}
+	if q.policy == AsyncDropNewest || (q.policy == AsyncDropBelow && m.level > q.level) {
+		select {
+		case q.ch <- item:
+		default:
+			q.c.dropped.Add(1)
+			q.c.metrics.countDropped(m.level)
+		}
+		return true
+	}
+	q.ch <- item
+	return true
+}
+
+func (q *asyncQueue) flush() error {
+	flushed := make(chan struct{})
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return nil
+	}
+	q.ch <- asyncItem{flushed: flushed}
+	q.mu.RUnlock()
+	<-flushed
+	q.errMu.Lock()
+	defer q.errMu.Unlock()
+	err := q.err
+	q.err = nil
+	return err
+}
+
+func (q *asyncQueue) close() error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.ch)
+	}
+	q.mu.Unlock()
+	<-q.done
+	q.errMu.Lock()
+	defer q.errMu.Unlock()
+	return q.err
+}
+
+// SetAsync enables asynchronous output. Messages are formatted by the caller and
+// written by a background goroutine through a queue of the given size.
+// When the queue is full, policy decides whether to block or to drop the message;
+// AsyncDropBelow drops messages less severe than level.
+// A size of 0 or less disables asynchronous output after writing pending messages.
+func (l *Logger) SetAsync(size int, policy AsyncPolicy, level Level) {
+	var q *asyncQueue
+	if size > 0 {
+		q = newAsyncQueue(l.core, size, policy, level)
+	}
+	if old := l.async.Swap(q); old != nil {
+		_ = old.close()
+	}
+}
+
+// flushDedup writes the pending repeat count of collapsed messages.
+func (c *core) flushDedup() error {
+	d := c.dedup.Load()
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.flush(c, time.Now())
+}
+
+// flushOutput waits for outputs that write in the background, e.g. remote syslog.
+func (c *core) flushOutput() error {
+	/* the outputs are flushed without the lock, which would block logging meanwhile */
+	c.outMu.Lock()
+	out := c.out
+	var fallback output
+	if c.failover != nil {
+		fallback = c.failover.fallback
+	}
+	c.outMu.Unlock()
+	var err error
+	for _, o := range [...]output{out, fallback} {
+		if f, ok := o.(flusher); ok {
+			if ferr := f.flush(); err == nil {
+				err = ferr
+			}
+		}
+	}
+	return err
+}
+
+// Flush writes pending repeat counts and waits until all queued messages are written,
+// including the backlog of remote syslog outputs for a limited time.
+// It returns the first write error since the last flush, if any.
+func (l *Logger) Flush() error {
+	err := l.flushDedup()
+	if q := l.async.Load(); q != nil {
+		if qerr := q.flush(); err == nil {
+			err = qerr
+		}
+	}
+	if ferr := l.flushOutput(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// Close writes all pending messages and disables asynchronous output.
+// It returns the first write error since the last flush, if any.
+func (l *Logger) Close() error {
+	err := l.flushDedup()
+	if q := l.async.Swap(nil); q != nil {
+		if qerr := q.close(); err == nil {
+			err = qerr
+		}
+	}
+	if ferr := l.flushOutput(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// Dropped returns the number of messages dropped because the asynchronous queue was full.
+func (l *Logger) Dropped() uint64 {
+	return l.dropped.Load()
+}
@@ -0,0 +1,132 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+// gateWriter blocks every write until the gate is opened.
+type gateWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	entered chan struct{}
+	gate    chan struct{}
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{
+		entered: make(chan struct{}, 100),
+		gate:    make(chan struct{}),
+	}
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	w.entered <- struct{}{}
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gateWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncFlush(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetAsync(16, slog.AsyncBlock, slog.LevelSilence)
+	defer logger.Close()
+
+	args := []byte("before")
+	logger.Debugf("message %s", args)
+	copy(args, "after!")
+	for i := 0; i < 100; i++ {
+		logger.Debugf("line %d", i)
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "message before") {
+		t.Errorf("expected message formatted at call time, got: %s", output)
+	}
+	if lines := strings.Count(output, "\n"); lines != 101 {
+		t.Errorf("expected 101 lines, got %d", lines)
+	}
+	if logger.Dropped() != 0 {
+		t.Errorf("expected no dropped messages, got %d", logger.Dropped())
+	}
+}
+
+func TestAsyncDropNewest(t *testing.T) {
+	w := newGateWriter()
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, w)
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetAsync(1, slog.AsyncDropNewest, slog.LevelSilence)
+
+	logger.Debug("first")
+	<-w.entered // the writer goroutine is now blocked
+	logger.Debug("queued")
+	logger.Debug("dropped")
+	logger.Error("dropped")
+	if logger.Dropped() != 2 {
+		t.Errorf("expected 2 dropped messages, got %d", logger.Dropped())
+	}
+	close(w.gate)
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	output := w.String()
+	if !strings.Contains(output, "first") || !strings.Contains(output, "queued") || strings.Contains(output, "dropped") {
+		t.Errorf("unexpected output: %s", output)
+	}
+
+	// synchronous after close
+	logger.Debug("sync")
+	if !strings.Contains(w.String(), "sync") {
+		t.Errorf("expected synchronous output after close, got: %s", w.String())
+	}
+}
+
+func TestAsyncDropBelow(t *testing.T) {
+	w := newGateWriter()
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, w)
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetAsync(1, slog.AsyncDropBelow, slog.LevelWarning)
+
+	logger.Debug("first")
+	<-w.entered
+	logger.Debug("queued")
+	logger.Debug("dropped")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		logger.Error("blocking")
+	}()
+	close(w.gate)
+	<-done
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	output := w.String()
+	if !strings.Contains(output, "blocking") || strings.Contains(output, "dropped") {
+		t.Errorf("unexpected output: %s", output)
+	}
+	if logger.Dropped() != 1 {
+		t.Errorf("expected 1 dropped message, got %d", logger.Dropped())
+	}
+}
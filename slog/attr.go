@@ -0,0 +1,215 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Kind is the type of an attribute value.
+type Kind int
+
+const (
+	KindAny Kind = iota
+	KindString
+	KindInt64
+	KindUint64
+	KindFloat64
+	KindBool
+	KindDuration
+	KindTime
+)
+
+// Attr is a typed key/value pair attached to a log message.
+type Attr struct {
+	Key  string
+	kind Kind
+	num  uint64
+	str  string
+	any  any
+}
+
+// String returns an Attr for a string value.
+func String(key, value string) Attr {
+	return Attr{Key: key, kind: KindString, str: value}
+}
+
+// Int returns an Attr for an int value.
+func Int(key string, value int) Attr {
+	return Int64(key, int64(value))
+}
+
+// Int64 returns an Attr for an int64 value.
+func Int64(key string, value int64) Attr {
+	return Attr{Key: key, kind: KindInt64, num: uint64(value)}
+}
+
+// Uint64 returns an Attr for a uint64 value.
+func Uint64(key string, value uint64) Attr {
+	return Attr{Key: key, kind: KindUint64, num: value}
+}
+
+// Float64 returns an Attr for a float64 value.
+func Float64(key string, value float64) Attr {
+	return Attr{Key: key, kind: KindFloat64, num: math.Float64bits(value)}
+}
+
+// Bool returns an Attr for a bool value.
+func Bool(key string, value bool) Attr {
+	var num uint64
+	if value {
+		num = 1
+	}
+	return Attr{Key: key, kind: KindBool, num: num}
+}
+
+// Duration returns an Attr for a time.Duration value.
+func Duration(key string, value time.Duration) Attr {
+	return Attr{Key: key, kind: KindDuration, num: uint64(value)}
+}
+
+// Time returns an Attr for a time.Time value.
+func Time(key string, value time.Time) Attr {
+	return Attr{Key: key, kind: KindTime, any: value}
+}
+
+// Err returns an Attr for an error value with the key "error".
+func Err(err error) Attr {
+	return Attr{Key: "error", kind: KindAny, any: err}
+}
+
+// Any returns an Attr for an arbitrary value, using the most specific kind available.
+func Any(key string, value any) Attr {
+	switch v := value.(type) {
+	case string:
+		return String(key, v)
+	case int:
+		return Int(key, v)
+	case int64:
+		return Int64(key, v)
+	case int32:
+		return Int64(key, int64(v))
+	case uint:
+		return Uint64(key, uint64(v))
+	case uint64:
+		return Uint64(key, v)
+	case uint32:
+		return Uint64(key, uint64(v))
+	case float64:
+		return Float64(key, v)
+	case bool:
+		return Bool(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	}
+	return Attr{Key: key, kind: KindAny, any: value}
+}
+
+// Kind returns the kind of the attribute value.
+func (a Attr) Kind() Kind {
+	return a.kind
+}
+
+// Value returns the attribute value as a Go value.
+func (a Attr) Value() any {
+	switch a.kind {
+	case KindString:
+		return a.str
+	case KindInt64:
+		return int64(a.num)
+	case KindUint64:
+		return a.num
+	case KindFloat64:
+		return math.Float64frombits(a.num)
+	case KindBool:
+		return a.num != 0
+	case KindDuration:
+		return time.Duration(a.num)
+	}
+	return a.any
+}
+
+// AppendValue appends the unquoted text form of the attribute value to b.
+func (a Attr) AppendValue(b []byte) []byte {
+	switch a.kind {
+	case KindString:
+		return append(b, a.str...)
+	case KindInt64:
+		return strconv.AppendInt(b, int64(a.num), 10)
+	case KindUint64:
+		return strconv.AppendUint(b, a.num, 10)
+	case KindFloat64:
+		return strconv.AppendFloat(b, math.Float64frombits(a.num), 'g', -1, 64)
+	case KindBool:
+		return strconv.AppendBool(b, a.num != 0)
+	case KindDuration:
+		return append(b, time.Duration(a.num).String()...)
+	case KindTime:
+		return a.any.(time.Time).AppendFormat(b, time.RFC3339Nano)
+	}
+	switch v := a.any.(type) {
+	case nil:
+		return append(b, "<nil>"...)
+	case error:
+		return append(b, v.Error()...)
+	case fmt.Stringer:
+		return append(b, v.String()...)
+	}
+	return fmt.Append(b, a.any)
+}
+
+func needsQuoting(s []byte) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c <= ' ' || c == '"' || c == '=' || c == 0x7f {
+				return true
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError || !strconv.IsPrint(r) {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// appendQuotedValue appends the attribute value to b, quoting it when necessary.
+func appendQuotedValue(b []byte, a Attr) []byte {
+	start := len(b)
+	b = a.AppendValue(b)
+	if needsQuoting(b[start:]) {
+		var buf [64]byte
+		v := append(buf[:0], b[start:]...)
+		b = strconv.AppendQuote(b[:start], string(v))
+	}
+	return b
+}
+
+// appendAttrText appends " key=value" to b.
+func appendAttrText(b []byte, a Attr) []byte {
+	b = append(b, ' ')
+	b = append(b, a.Key...)
+	b = append(b, '=')
+	return appendQuotedValue(b, a)
+}
+
+func appendAttrs(b []byte, attrs []Attr) []byte {
+	for _, a := range attrs {
+		b = appendAttrText(b, a)
+	}
+	return b
+}
@@ -0,0 +1,97 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestAttrValue(t *testing.T) {
+	tests := []struct {
+		attr slog.Attr
+		kind slog.Kind
+		text string
+	}{
+		{slog.String("s", "hello"), slog.KindString, "hello"},
+		{slog.Int("i", -42), slog.KindInt64, "-42"},
+		{slog.Uint64("u", 42), slog.KindUint64, "42"},
+		{slog.Float64("f", 1.5), slog.KindFloat64, "1.5"},
+		{slog.Bool("b", true), slog.KindBool, "true"},
+		{slog.Duration("d", 1500*time.Millisecond), slog.KindDuration, "1.5s"},
+		{slog.Err(errors.New("oops")), slog.KindAny, "oops"},
+		{slog.Any("a", 7), slog.KindInt64, "7"},
+		{slog.Any("a", []int{1, 2}), slog.KindAny, "[1 2]"},
+	}
+	for _, tt := range tests {
+		if tt.attr.Kind() != tt.kind {
+			t.Errorf("%s: expected kind %d, got %d", tt.attr.Key, tt.kind, tt.attr.Kind())
+		}
+		if got := string(tt.attr.AppendValue(nil)); got != tt.text {
+			t.Errorf("%s: expected %q, got %q", tt.attr.Key, tt.text, got)
+		}
+	}
+}
+
+func TestLoggerAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelInfo)
+
+	logger.Infow("accepted", slog.String("peer", "127.0.0.1:80"), slog.Int("conn", 3),
+		slog.String("note", "has space"), slog.String("empty", ""))
+
+	output := buf.String()
+	for _, want := range []string{
+		"accepted peer=127.0.0.1:80 conn=3",
+		`note="has space"`,
+		`empty=""`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %s", want, output)
+		}
+	}
+
+	buf.Reset()
+	logger.Debugw("filtered", slog.Bool("x", true))
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got: %s", buf.String())
+	}
+}
+
+func TestTerminalAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputTerminal, &buf)
+	logger.SetLevel(slog.LevelInfo)
+
+	logger.Warningw("slow", slog.Duration("rtt", time.Second))
+	output := buf.String()
+	if !strings.Contains(output, "\x1b[2mrtt=\x1b[22m1s") {
+		t.Errorf("expected colored attribute, got: %q", output)
+	}
+}
+
+func TestPackageLog(t *testing.T) {
+	var buf bytes.Buffer
+	slog.Default().SetOutput(slog.OutputWriter, &buf)
+	slog.Default().SetLevel(slog.LevelDebug)
+
+	slog.Log(0, slog.LevelDebug, nil, "log", slog.String("k", "v"))
+	slog.Errorw("errorw", slog.Err(errors.New("broken pipe")))
+
+	output := buf.String()
+	if !strings.Contains(output, "log k=v\n") {
+		t.Errorf("expected output to contain 'log k=v', got: %s", output)
+	}
+	if !strings.Contains(output, `errorw error="broken pipe"`) {
+		t.Errorf("expected output to contain error attribute, got: %s", output)
+	}
+}
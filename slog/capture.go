@@ -0,0 +1,208 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a rendered log message.
+type Record struct {
+	Time    time.Time
+	Level   Level
+	File    string
+	Line    int
+	Message string
+	Attrs   []Attr
+	// Extra is the payload written by the extra function, if any.
+	Extra []byte
+	// Binary is the data logged by Binary or Binaryf, if any, with masked bytes zeroed.
+	// Extra is its hex dump.
+	Binary []byte
+}
+
+// newRecord copies the message, returning the error from rendering the extra payload.
+func newRecord(m *message) (Record, error) {
+	file, line := m.caller()
+	r := Record{
+		Time:    m.timestamp,
+		Level:   m.level,
+		File:    file,
+		Line:    line,
+		Message: string(m.text),
+		Attrs:   append([]Attr(nil), m.attrs...),
+	}
+	var err error
+	if m.writeExtra != nil {
+		var extra bytes.Buffer
+		err = m.writeExtra(&extra)
+		r.Extra = extra.Bytes()
+	}
+	if m.binary != nil {
+		r.Binary = append([]byte(nil), m.binary.data...)
+	}
+	return r, err
+}
+
+// Attr returns the value of the last attribute with the given key.
+func (r *Record) Attr(key string) (any, bool) {
+	for i := len(r.Attrs) - 1; i >= 0; i-- {
+		if r.Attrs[i].Key == key {
+			return r.Attrs[i].Value(), true
+		}
+	}
+	return nil, false
+}
+
+// TB is the subset of testing.TB used by the test helpers.
+type TB interface {
+	Helper()
+	Log(args ...any)
+	Errorf(format string, args ...any)
+	Cleanup(func())
+}
+
+// Capture keeps all written messages in memory, mainly for tests.
+type Capture struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewCapture returns an empty Capture.
+func NewCapture() *Capture {
+	return &Capture{}
+}
+
+// Records returns a copy of all captured records, oldest first.
+func (c *Capture) Records() []Record {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Record(nil), c.records...)
+}
+
+// Reset removes all captured records.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = nil
+}
+
+// Find returns the first record at the given level whose message contains substr.
+func (c *Capture) Find(level Level, substr string) (Record, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.records {
+		if r.Level == level && strings.Contains(r.Message, substr) {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
+
+// AssertLogged reports a test error unless a message at the given level containing substr was captured.
+func (c *Capture) AssertLogged(t TB, level Level, substr string) Record {
+	t.Helper()
+	r, ok := c.Find(level, substr)
+	if !ok {
+		t.Errorf("slog: no %s message containing %q, captured:%s", level, substr, c)
+	}
+	return r
+}
+
+// AssertNotLogged reports a test error if any message at the given level or more severe was captured.
+func (c *Capture) AssertNotLogged(t TB, level Level) {
+	t.Helper()
+	for _, r := range c.Records() {
+		if r.Level != LevelSilence && r.Level <= level {
+			t.Errorf("slog: unexpected %s message %q", r.Level, r.Message)
+		}
+	}
+}
+
+// String returns the captured messages, one per line.
+func (c *Capture) String() string {
+	var b strings.Builder
+	for _, r := range c.Records() {
+		b.WriteString("\n")
+		b.WriteByte(levelChar[r.Level])
+		b.WriteByte(' ')
+		b.WriteString(r.Message)
+	}
+	return b.String()
+}
+
+type captureWriter struct {
+	c *Capture
+}
+
+func (w *captureWriter) WriteMsg(m *message) error {
+	r, err := newRecord(m)
+	w.c.mu.Lock()
+	defer w.c.mu.Unlock()
+	w.c.records = append(w.c.records, r)
+	return err
+}
+
+// testLogWriter passes each message to t.Log, including the extra payload.
+type testLogWriter struct {
+	t   TB
+	buf []byte
+}
+
+func (w *testLogWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+func (w *testLogWriter) Flush() error {
+	w.t.Helper()
+	w.t.Log(string(bytes.TrimSuffix(w.buf, []byte{'\n'})))
+	w.buf = w.buf[:0]
+	return nil
+}
+
+func newTestWriter(t TB) output {
+	return &textWriter{out: &testLogWriter{t: t}}
+}
+
+// replaceOutput replaces the output until the test ends, then restores the previous one.
+func (l *Logger) replaceOutput(t TB, w output) {
+	_ = l.Flush()
+	l.outMu.Lock()
+	prev := l.out
+	l.out = w
+	if l.failover != nil {
+		l.failover.reset()
+	}
+	prevLevel := Level(l.outLevel.Load())
+	l.setOutLevel(LevelSilence)
+	l.outMu.Unlock()
+	t.Cleanup(func() {
+		_ = l.Flush()
+		l.outMu.Lock()
+		l.out = prev
+		if l.failover != nil {
+			l.failover.reset()
+		}
+		l.setOutLevel(prevLevel)
+		l.outMu.Unlock()
+	})
+}
+
+// CaptureOutput replaces the output of the logger with a new Capture until the test ends.
+// The logger level is not changed.
+func (l *Logger) CaptureOutput(t TB) *Capture {
+	c := NewCapture()
+	l.replaceOutput(t, &captureWriter{c})
+	return c
+}
+
+// LogToTest replaces the output of the logger with t.Log until the test ends,
+// so that messages are shown along with the test that wrote them.
+func (l *Logger) LogToTest(t TB) {
+	l.replaceOutput(t, newTestWriter(t))
+}
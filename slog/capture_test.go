@@ -0,0 +1,142 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+// fakeTB records failures instead of failing the test.
+type fakeTB struct {
+	logs     []string
+	errors   []string
+	cleanups []func()
+}
+
+func (t *fakeTB) Helper()                   {}
+func (t *fakeTB) Log(args ...any)           { t.logs = append(t.logs, fmt.Sprint(args...)) }
+func (t *fakeTB) Cleanup(f func())          { t.cleanups = append(t.cleanups, f) }
+func (t *fakeTB) Errorf(f string, a ...any) { t.errors = append(t.errors, fmt.Sprintf(f, a...)) }
+
+func (t *fakeTB) cleanup() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestCaptureOutput(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+
+	logger.Warningw("disk almost full", slog.Int("percent", 95))
+	logger.Println(0, slog.LevelInfo, func(w io.Writer) error {
+		_, err := io.WriteString(w, "payload\n")
+		return err
+	}, "with", "extra")
+	logger.Debug("filtered")
+
+	records := c.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	r := c.AssertLogged(t, slog.LevelWarning, "almost full")
+	if v, ok := r.Attr("percent"); !ok || v != int64(95) {
+		t.Errorf("unexpected attribute: %v", v)
+	}
+	if !strings.HasSuffix(r.File, "capture_test.go") || r.Line == 0 {
+		t.Errorf("unexpected source location: %s:%d", r.File, r.Line)
+	}
+	if records[1].Message != "with extra" || string(records[1].Extra) != "payload\n" {
+		t.Errorf("unexpected record: %+v", records[1])
+	}
+	c.AssertNotLogged(t, slog.LevelError)
+
+	fake := &fakeTB{}
+	c.AssertLogged(fake, slog.LevelError, "almost full")
+	c.AssertNotLogged(fake, slog.LevelWarning)
+	if len(fake.errors) != 2 {
+		t.Errorf("expected 2 assertion failures, got %q", fake.errors)
+	}
+
+	c.Reset()
+	if len(c.Records()) != 0 {
+		t.Error("expected no records after reset")
+	}
+}
+
+func TestCaptureRestore(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelInfo)
+
+	fake := &fakeTB{}
+	c := logger.CaptureOutput(fake)
+	logger.Info("captured")
+	fake.cleanup()
+	logger.Info("restored")
+
+	if _, ok := c.Find(slog.LevelInfo, "captured"); !ok {
+		t.Error("expected captured message")
+	}
+	if output := buf.String(); strings.Contains(output, "captured") || !strings.Contains(output, "restored") {
+		t.Errorf("unexpected output: %s", output)
+	}
+}
+
+func TestLogToTest(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	fake := &fakeTB{}
+	logger.LogToTest(fake)
+	logger.Println(0, slog.LevelInfo, func(w io.Writer) error {
+		_, err := io.WriteString(w, "payload\n")
+		return err
+	}, "hello")
+	fake.cleanup()
+	logger.Info("discarded")
+
+	if len(fake.logs) != 1 {
+		t.Fatalf("expected 1 test log, got %q", fake.logs)
+	}
+	if log := fake.logs[0]; !strings.HasPrefix(log, "I ") || !strings.HasSuffix(log, "hello\npayload") {
+		t.Errorf("unexpected test log: %q", log)
+	}
+}
+
+func TestCapturePackageLevelFunctions(t *testing.T) {
+	origLevel := slog.Default().Level()
+	defer slog.Default().SetLevel(origLevel)
+	c := slog.Default().CaptureOutput(t)
+	slog.Default().SetLevel(slog.LevelVeryVerbose)
+
+	tests := []struct {
+		name    string
+		logFunc func()
+		level   slog.Level
+	}{
+		{"Fatalw", func() { slog.Fatalw("fatal msg") }, slog.LevelFatal},
+		{"Errorw", func() { slog.Errorw("error msg") }, slog.LevelError},
+		{"Warningw", func() { slog.Warningw("warn msg") }, slog.LevelWarning},
+		{"Noticew", func() { slog.Noticew("notice msg") }, slog.LevelNotice},
+		{"Infow", func() { slog.Infow("info msg") }, slog.LevelInfo},
+		{"Debugw", func() { slog.Debugw("debug msg") }, slog.LevelDebug},
+		{"Verbosew", func() { slog.Verbosew("verbose msg") }, slog.LevelVerbose},
+		{"VeryVerbosew", func() { slog.VeryVerbosew("vv msg") }, slog.LevelVeryVerbose},
+	}
+	for _, tt := range tests {
+		c.Reset()
+		tt.logFunc()
+		records := c.Records()
+		if len(records) != 1 || records[0].Level != tt.level {
+			t.Errorf("%s: expected one %s message, got:%s", tt.name, tt.level, c)
+		}
+	}
+}
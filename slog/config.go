@@ -0,0 +1,308 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config specifies logger settings, typically loaded from a configuration file.
+// Empty fields leave the corresponding settings unchanged.
+type Config struct {
+	// Output is an output spec as accepted by SetOutputSpec.
+	Output string `json:"output,omitempty"`
+	// Level is a level as accepted by ParseLevel.
+	Level string `json:"level,omitempty"`
+	// Flags is a list of flags as accepted by ParseFlags.
+	Flags string `json:"flags,omitempty"`
+	// Format is a line layout as accepted by SetFormat.
+	Format string `json:"format,omitempty"`
+	// Modules is a list of per-module levels as accepted by SetModuleLevels.
+	Modules string `json:"modules,omitempty"`
+}
+
+// Configure validates all settings in cfg before applying any of them.
+func (l *Logger) Configure(cfg *Config) error {
+	level := l.Level()
+	if cfg.Level != "" {
+		var err error
+		if level, err = ParseLevel(cfg.Level); err != nil {
+			return err
+		}
+	}
+	flags := l.Flags()
+	if cfg.Flags != "" {
+		var err error
+		if flags, err = ParseFlags(cfg.Flags); err != nil {
+			return err
+		}
+	}
+	if cfg.Format != "" {
+		if _, err := parseFormat(cfg.Format); err != nil {
+			return err
+		}
+	}
+	if _, err := parseVModule(cfg.Modules); err != nil {
+		return err
+	}
+	var w output
+	if cfg.Output != "" {
+		var err error
+		if w, err = parseOutputSpec(cfg.Output); err != nil {
+			return err
+		}
+	}
+
+	if w != nil {
+		l.setOutput(w)
+	}
+	l.SetLevel(level)
+	l.SetFlags(flags)
+	if cfg.Format != "" {
+		_ = l.SetFormat(cfg.Format)
+	}
+	if cfg.Modules != "" {
+		_ = l.SetModuleLevels(cfg.Modules)
+	}
+	return nil
+}
+
+// SetOutputSpec replaces all existing outputs with the one described by spec:
+//
+//	stdout, stderr    text, colored if ColorEnabled; "?color=always" or "?color=never" overrides
+//	discard           no output
+//	json:stdout       JSON lines to stdout or stderr
+//	file:PATH         text to a file; options: rotate=SIZE (e.g. "100MB"), age=DURATION,
+//	                  backups=N, compress=BOOL, reopen=BOOL (on SIGHUP)
+//	syslog[:TAG]      the system logger, tagged with TAG or the program name
+//	journal[:TAG]     systemd-journald; option: socket=PATH
+//	syslog+udp:ADDR   a remote syslog server over UDP, TCP ("syslog+tcp") or TLS ("syslog+tls");
+//	                  options: facility=NAME, hostname=NAME, app=NAME, rfc3164=BOOL, backlog=N
+//
+// Options are appended like a URL query, e.g. "file:/var/log/app.log?rotate=100MB&backups=5".
+// On error, the current output is kept.
+func (l *Logger) SetOutputSpec(spec string) error {
+	w, err := parseOutputSpec(spec)
+	if err != nil {
+		return err
+	}
+	l.setOutput(w)
+	return nil
+}
+
+func parseOutputSpec(spec string) (output, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	arg, rawQuery, _ := strings.Cut(arg, "?")
+	if strings.Contains(kind, "?") {
+		kind, rawQuery, _ = strings.Cut(kind, "?")
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("slog: invalid output spec %q: %w", spec, err)
+	}
+	opts := specOptions{spec: spec, query: query}
+	var t OutputType
+	var args []any
+	switch strings.ToLower(kind) {
+	case "stdout", "stderr":
+		out := os.Stdout
+		if strings.EqualFold(kind, "stderr") {
+			out = os.Stderr
+		}
+		switch color := opts.get("color"); color {
+		case "", "auto":
+			t = OutputAuto
+		case "always":
+			t = OutputTerminal
+		case "never":
+			t = OutputWriter
+		default:
+			return nil, fmt.Errorf("slog: invalid color %q in output spec %q", color, spec)
+		}
+		args = []any{out}
+	case "discard":
+		t = OutputDiscard
+	case "json":
+		t = OutputJSON
+		switch arg {
+		case "stdout":
+			args = []any{os.Stdout}
+		case "stderr":
+			args = []any{os.Stderr}
+		default:
+			return nil, fmt.Errorf("slog: invalid JSON target %q in output spec %q", arg, spec)
+		}
+	case "file":
+		if arg == "" {
+			return nil, fmt.Errorf("slog: missing path in output spec %q", spec)
+		}
+		t = OutputFile
+		args = []any{arg, &RotateConfig{
+			MaxSize:        opts.size("rotate"),
+			MaxAge:         opts.duration("age"),
+			MaxBackups:     opts.int("backups"),
+			Compress:       opts.bool("compress"),
+			ReopenOnHangup: opts.bool("reopen"),
+		}}
+	case "syslog", "journal":
+		if arg == "" {
+			arg = filepath.Base(os.Args[0])
+		}
+		t, args = OutputSyslog, []any{arg}
+		if strings.EqualFold(kind, "journal") {
+			t, args = OutputJournal, append(args, opts.get("socket"))
+		}
+	case "syslog+udp", "syslog+tcp", "syslog+tls":
+		cfg := &RemoteSyslogConfig{
+			Network:  strings.ToLower(kind[len("syslog+"):]),
+			Addr:     arg,
+			Facility: opts.facility("facility"),
+			Hostname: opts.get("hostname"),
+			AppName:  opts.get("app"),
+			RFC3164:  opts.bool("rfc3164"),
+			Backlog:  opts.int("backlog"),
+		}
+		t, args = OutputRemoteSyslog, []any{cfg}
+	default:
+		return nil, fmt.Errorf("slog: unknown output %q in output spec %q", kind, spec)
+	}
+	if err := opts.check(); err != nil {
+		return nil, err
+	}
+	return newOutput(t, args...)
+}
+
+// specOptions parses the query of an output spec, keeping the first error.
+type specOptions struct {
+	spec  string
+	query url.Values
+	used  []string
+	err   error
+}
+
+func (o *specOptions) get(key string) string {
+	o.used = append(o.used, key)
+	return o.query.Get(key)
+}
+
+func (o *specOptions) fail(key, value string, err error) {
+	if o.err == nil {
+		o.err = fmt.Errorf("slog: invalid %s %q in output spec %q: %w", key, value, o.spec, err)
+	}
+}
+
+func (o *specOptions) int(key string) int {
+	s := o.get(key)
+	if s == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		o.fail(key, s, err)
+	}
+	return n
+}
+
+func (o *specOptions) bool(key string) bool {
+	s := o.get(key)
+	if s == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		o.fail(key, s, err)
+	}
+	return b
+}
+
+func (o *specOptions) duration(key string) time.Duration {
+	s := o.get(key)
+	if s == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		o.fail(key, s, err)
+	}
+	return d
+}
+
+func (o *specOptions) size(key string) int64 {
+	s := o.get(key)
+	if s == "" {
+		return 0
+	}
+	n, err := parseSize(s)
+	if err != nil {
+		o.fail(key, s, err)
+	}
+	return n
+}
+
+func (o *specOptions) facility(key string) int {
+	s := o.get(key)
+	if s == "" {
+		return 0
+	}
+	f, err := ParseFacility(s)
+	if err != nil {
+		o.fail(key, s, err)
+	}
+	return f
+}
+
+// check returns the first parse error or an unknown option, if any.
+func (o *specOptions) check() error {
+	if o.err != nil {
+		return o.err
+	}
+	for key := range o.query {
+		found := false
+		for _, used := range o.used {
+			if key == used {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("slog: unknown option %q in output spec %q", key, o.spec)
+		}
+	}
+	return nil
+}
+
+var sizeUnits = []struct {
+	suffix string
+	scale  int64
+}{
+	{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30},
+	{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30},
+	{"k", 1 << 10}, {"m", 1 << 20}, {"g", 1 << 30},
+	{"b", 1},
+}
+
+// parseSize parses a byte size like "512", "64KiB" or "100MB". All units are powers of 1024.
+func parseSize(s string) (int64, error) {
+	num, scale := strings.ToLower(strings.TrimSpace(s)), int64(1)
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(num, u.suffix) {
+			num, scale = strings.TrimSpace(strings.TrimSuffix(num, u.suffix)), u.scale
+			break
+		}
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > (1<<63-1)/scale {
+		return 0, strconv.ErrRange
+	}
+	return n * scale, nil
+}
@@ -0,0 +1,121 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestSetOutputErrors(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputWriter, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		t    slog.OutputType
+		v    []any
+	}{
+		{"missing writer", slog.OutputWriter, nil},
+		{"nil writer", slog.OutputTerminal, []any{nil}},
+		{"wrong type", slog.OutputJSON, []any{"stderr"}},
+		{"wrong config", slog.OutputFile, []any{filepath.Join(t.TempDir(), "x.log"), 100}},
+		{"bad path", slog.OutputFile, []any{filepath.Join(t.TempDir(), "missing", "x.log")}},
+		{"unknown type", slog.OutputType(-1), nil},
+	}
+	for _, tt := range tests {
+		if err := logger.SetOutput(tt.t, tt.v...); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+		if err := logger.AddOutput(slog.LevelInfo, slog.FlagNone, tt.t, tt.v...); err == nil {
+			t.Errorf("%s: expected error from AddOutput", tt.name)
+		}
+	}
+	logger.Info("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Errorf("expected previous output to be kept, got: %s", buf.String())
+	}
+}
+
+func TestSetOutputSpec(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+
+	for _, spec := range []string{
+		"stderr", "stdout?color=never", "discard", "json:stderr",
+		"file:" + path + "?rotate=100MB&age=24h&backups=3&compress=true",
+	} {
+		if err := logger.SetOutputSpec(spec); err != nil {
+			t.Errorf("SetOutputSpec(%q): %v", spec, err)
+		}
+	}
+	logger.Info("to file")
+	if err := logger.SetOutputSpec("discard"); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(path); err != nil || !strings.Contains(string(b), "to file") {
+		t.Errorf("unexpected file content: %q, %v", b, err)
+	}
+
+	for _, spec := range []string{
+		"", "foo", "stderr?color=rainbow", "stderr?colour=always", "json:/tmp/x",
+		"file:", "file:" + path + "?rotate=lots", "file:" + path + "?backups=x",
+		"file:" + path + "?age=1", "file:" + filepath.Join(dir, "missing", "x.log"),
+		"journal:test?socket=" + filepath.Join(dir, "missing"),
+	} {
+		if err := logger.SetOutputSpec(spec); err == nil {
+			t.Errorf("SetOutputSpec(%q): expected error", spec)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+
+	bad := []slog.Config{
+		{Level: "loud"},
+		{Flags: "utc,bold"},
+		{Format: "%q"},
+		{Modules: "net="},
+		{Output: "file:"},
+		{Level: "debug", Output: "foo"},
+	}
+	for _, cfg := range bad {
+		if err := logger.Configure(&cfg); err == nil {
+			t.Errorf("Configure(%+v): expected error", cfg)
+		}
+	}
+	if logger.Level() != slog.LevelInfo {
+		t.Errorf("expected level to be unchanged, got %s", logger.Level())
+	}
+	logger.Info("captured")
+	c.AssertLogged(t, slog.LevelInfo, "captured")
+
+	var buf bytes.Buffer
+	logger.SetOutput(slog.OutputWriter, &buf)
+	if err := logger.Configure(&slog.Config{Level: "warning", Flags: "utc", Format: "%L: %m"}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("filtered")
+	logger.Warning("shown")
+	if output := buf.String(); output != "warning: shown\n" {
+		t.Errorf("unexpected output %q", output)
+	}
+	if logger.Flags() != slog.FlagUTC {
+		t.Errorf("unexpected flags %s", logger.Flags())
+	}
+}
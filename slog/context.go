@@ -0,0 +1,119 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"context"
+	"io"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the logger.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger if there is none.
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*Logger); ok {
+			return l
+		}
+	}
+	return std
+}
+
+// ContextWith returns a copy of ctx carrying a child of its logger with the given attributes,
+// e.g. a request ID or a peer address, so that callees can log them without passing a logger
+// by calling InfowContext(ctx, msg) or FromContext(ctx).Infof(format, v...).
+func ContextWith(ctx context.Context, attrs ...Attr) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(attrs...))
+}
+
+// ContextWithPrefix returns a copy of ctx carrying a child of its logger with the given prefix.
+func ContextWithPrefix(ctx context.Context, prefix string) context.Context {
+	return NewContext(ctx, FromContext(ctx).WithPrefix(prefix))
+}
+
+// LogContext writes a log message with the logger carried by ctx if the level is enabled.
+func LogContext(ctx context.Context, calldepth int, level Level, extra func(io.Writer) error, msg string, attrs ...Attr) error {
+	l := FromContext(ctx)
+	if !l.CheckLevel(level) {
+		return nil
+	}
+	return l.output(calldepth+1, level, msgString, msg, nil, extra, attrs)
+}
+
+// FatalwContext logs serious problems that are likely to cause the program to exit, with attributes, using the logger carried by ctx.
+func FatalwContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelFatal) {
+		return
+	}
+	l.output(1, LevelFatal, msgString, msg, nil, nil, attrs)
+}
+
+// ErrorwContext logs issues that shouldn't be ignored, with attributes, using the logger carried by ctx.
+func ErrorwContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelError) {
+		return
+	}
+	l.output(1, LevelError, msgString, msg, nil, nil, attrs)
+}
+
+// WarningwContext logs issues that may be ignored, with attributes, using the logger carried by ctx.
+func WarningwContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelWarning) {
+		return
+	}
+	l.output(1, LevelWarning, msgString, msg, nil, nil, attrs)
+}
+
+// NoticewContext logs important status changes, with attributes, using the logger carried by ctx.
+func NoticewContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelNotice) {
+		return
+	}
+	l.output(1, LevelNotice, msgString, msg, nil, nil, attrs)
+}
+
+// InfowContext logs normal work reports, with attributes, using the logger carried by ctx.
+func InfowContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelInfo) {
+		return
+	}
+	l.output(1, LevelInfo, msgString, msg, nil, nil, attrs)
+}
+
+// DebugwContext logs extra information for debugging, with attributes, using the logger carried by ctx.
+func DebugwContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelDebug) {
+		return
+	}
+	l.output(1, LevelDebug, msgString, msg, nil, nil, attrs)
+}
+
+// VerbosewContext logs details for inspecting specific issues, with attributes, using the logger carried by ctx.
+func VerbosewContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelVerbose) {
+		return
+	}
+	l.output(1, LevelVerbose, msgString, msg, nil, nil, attrs)
+}
+
+// VeryVerbosewContext logs more details, with attributes, using the logger carried by ctx.
+func VeryVerbosewContext(ctx context.Context, msg string, attrs ...Attr) {
+	l := FromContext(ctx)
+	if !l.CheckLevel(LevelVeryVerbose) {
+		return
+	}
+	l.output(1, LevelVeryVerbose, msgString, msg, nil, nil, attrs)
+}
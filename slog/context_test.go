@@ -0,0 +1,68 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestContext(t *testing.T) {
+	if slog.FromContext(context.Background()) != slog.Default() {
+		t.Error("expected default logger without a logger in context")
+	}
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+
+	ctx := slog.NewContext(context.Background(), logger)
+	ctx = slog.ContextWith(ctx, slog.String("request", "r1"))
+	ctx = slog.ContextWithPrefix(ctx, "[r1] ")
+	handle := func(ctx context.Context) {
+		ctx = slog.ContextWith(ctx, slog.String("peer", "10.0.0.1"))
+		slog.FromContext(ctx).Infow("handled", slog.Int("status", 200))
+		slog.WarningwContext(ctx, "slow", slog.Int("ms", 1500))
+	}
+	handle(ctx)
+	slog.FromContext(ctx).Info("done")
+
+	r := c.AssertLogged(t, slog.LevelInfo, "[r1] handled")
+	var keys []string
+	for _, a := range r.Attrs {
+		keys = append(keys, a.Key)
+	}
+	if got := strings.Join(keys, ","); got != "request,peer,status" {
+		t.Errorf("unexpected attributes: %s", got)
+	}
+	if r := c.AssertLogged(t, slog.LevelWarning, "[r1] slow"); !strings.HasSuffix(r.File, "context_test.go") || len(r.Attrs) != 3 {
+		t.Errorf("unexpected record: %+v", r)
+	}
+	if r := c.AssertLogged(t, slog.LevelInfo, "done"); len(r.Attrs) != 1 {
+		t.Errorf("expected only the request attribute, got %+v", r.Attrs)
+	}
+}
+
+func TestLogContextLevel(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelWarning)
+	c := logger.CaptureOutput(t)
+	ctx := slog.NewContext(context.Background(), logger)
+
+	if err := slog.LogContext(ctx, 0, slog.LevelVeryVerbose, nil, "filtered"); err != nil {
+		t.Error(err)
+	}
+	if err := slog.LogContext(ctx, 0, slog.LevelError, nil, "written"); err != nil {
+		t.Error(err)
+	}
+	slog.DebugwContext(ctx, "filtered")
+	slog.ErrorwContext(ctx, "written")
+	records := c.Records()
+	if len(records) != 2 || records[0].Message != "written" || records[1].Message != "written" {
+		t.Errorf("unexpected records: %+v", records)
+	}
+}
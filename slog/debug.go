@@ -4,9 +4,13 @@
 package slog
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"os"
 	"runtime"
+	"strings"
+	"time"
 	"unicode"
 
 	"github.com/mattn/go-runewidth"
@@ -119,11 +123,9 @@ func Textf(level Level, txt string, wrap int, format string, v ...any) {
 	if !CheckLevel(level) {
 		return
 	}
-	std.output(1, level, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, func(w io.Writer) error {
+	std.output(1, level, msgPrintf, format, v, func(w io.Writer) error {
 		return writeText(w, txt, wrap)
-	})
+	}, nil)
 }
 
 // Text logs a long text message at the given level.
@@ -131,14 +133,13 @@ func Text(level Level, txt string, v ...any) {
 	if !CheckLevel(level) {
 		return
 	}
-	std.output(1, level, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, func(w io.Writer) error {
+	std.output(1, level, msgPrint, "", v, func(w io.Writer) error {
 		return writeText(w, txt, 0)
-	})
+	}, nil)
 }
 
-func writeBinary(w io.Writer, bin []byte, binWrap int) error {
+// writeBinary writes a hex dump of bin. Bytes marked in mask, if any, are dumped as "**".
+func writeBinary(w io.Writer, bin []byte, mask []bool, binWrap int) error {
 	if binWrap < 1 {
 		binWrap = 16
 	}
@@ -148,6 +149,10 @@ func writeBinary(w io.Writer, bin []byte, binWrap int) error {
 		b = fmt.Appendf(b, "%s%p: ", indent, bin[i:])
 		for j := 0; j < binWrap; j++ {
 			if (i + j) < len(bin) {
+				if mask != nil && mask[i+j] {
+					b = append(b, "** "...)
+					continue
+				}
 				b = fmt.Appendf(b, "%02X ", bin[i+j])
 			} else {
 				b = append(b, "   "...)
@@ -158,7 +163,9 @@ func writeBinary(w io.Writer, bin []byte, binWrap int) error {
 			r := ' '
 			if (i + j) < len(bin) {
 				r = rune(bin[i+j])
-				if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+				if mask != nil && mask[i+j] {
+					r = '*'
+				} else if r > unicode.MaxASCII || !unicode.IsPrint(r) {
 					r = '.'
 				}
 			}
@@ -173,16 +180,30 @@ func writeBinary(w io.Writer, bin []byte, binWrap int) error {
 	return nil
 }
 
+// binaryDump is the extra payload of Binary, kept as data so that hooks can redact it.
+type binaryDump struct {
+	data []byte
+	wrap int
+	mask []bool // bytes masked by hooks
+}
+
+func (d *binaryDump) write(w io.Writer) error {
+	return writeBinary(w, d.data, d.mask, d.wrap)
+}
+
+func (l *Logger) outputBinary(calldepth int, level Level, kind msgKind, format string, args []any, bin []byte, wrap int) error {
+	now := time.Now()
+	var pc [1]uintptr
+	runtime.Callers(calldepth+2, pc[:])
+	return l.write(now, level, pc[0], kind, format, args, nil, &binaryDump{data: bin, wrap: wrap}, nil)
+}
+
 // Binaryf logs binary data at the given level.
 func Binaryf(level Level, bin []byte, wrap int, format string, v ...any) {
 	if !CheckLevel(level) {
 		return
 	}
-	std.output(1, level, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, func(w io.Writer) error {
-		return writeBinary(w, bin, wrap)
-	})
+	std.outputBinary(1, level, msgPrintf, format, v, bin, wrap)
 }
 
 // Binary logs binary data at the given level.
@@ -190,11 +211,7 @@ func Binary(level Level, bin []byte, v ...any) {
 	if !CheckLevel(level) {
 		return
 	}
-	std.output(1, level, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, func(w io.Writer) error {
-		return writeBinary(w, bin, 0)
-	})
+	std.outputBinary(1, level, msgPrint, "", v, bin, 0)
 }
 
 func writeStacktrace(w io.Writer, pc []uintptr) error {
@@ -248,11 +265,9 @@ func Stackf(level Level, skip int, format string, v ...any) {
 	}
 	var pc [stackMaxDepth]uintptr
 	n := runtime.Callers(skip+2, pc[:])
-	std.output(1, level, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, func(w io.Writer) error {
+	std.output(1, level, msgPrintf, format, v, func(w io.Writer) error {
 		return writeStacktrace(w, pc[:n])
-	})
+	}, nil)
 }
 
 // Stack logs a stack trace at the given level.
@@ -262,12 +277,95 @@ func Stack(level Level, skip int, v ...any) {
 	}
 	var pc [stackMaxDepth]uintptr
 	n := runtime.Callers(skip+2, pc[:])
-	std.output(1, level, func(b []byte) []byte {
-		if len(v) == 0 {
-			return AppendMsg(b, "stack traceback:")
-		}
-		return AppendMsg(b, v...)
-	}, func(w io.Writer) error {
+	kind, msg := msgPrint, ""
+	if len(v) == 0 {
+		kind, msg = msgString, "stack traceback:"
+	}
+	std.output(1, level, kind, msg, v, func(w io.Writer) error {
 		return writeStacktrace(w, pc[:n])
-	})
+	}, nil)
+}
+
+// PanicAction specifies what Recover does after logging a panic.
+type PanicAction int
+
+const (
+	// PanicContinue stops the panic, so that the deferring function returns normally.
+	PanicContinue PanicAction = iota
+	// PanicRepanic panics again with the same value.
+	PanicRepanic
+	// PanicExit flushes the logger and exits the program with status 2.
+	PanicExit
+)
+
+// Recover logs a panic with its stack trace at LevelFatal, then acts as specified.
+// It must be deferred directly, e.g. "defer slog.Recover(slog.PanicExit)".
+func Recover(action PanicAction) {
+	if v := recover(); v != nil {
+		std.handlePanic(v, action)
+	}
+}
+
+// Recover logs a panic with its stack trace at LevelFatal, then acts as specified.
+// It must be deferred directly, e.g. "defer logger.Recover(slog.PanicContinue)".
+func (l *Logger) Recover(action PanicAction) {
+	if v := recover(); v != nil {
+		l.handlePanic(v, action)
+	}
+}
+
+func (l *Logger) handlePanic(v any, action PanicAction) {
+	var pc [stackMaxDepth]uintptr
+	n := runtime.Callers(3, pc[:])
+	if l.CheckLevel(LevelFatal) {
+		/* attribute the message to the function that panicked */
+		site := uintptr(0)
+		for _, p := range pc[:n] {
+			if f := runtime.FuncForPC(p - 1); f != nil && !strings.HasPrefix(f.Name(), "runtime.") {
+				site = p
+				break
+			}
+		}
+		_ = l.write(time.Now(), LevelFatal, site, msgPrintf, "panic: %v", []any{v}, func(w io.Writer) error {
+			return writeStacktrace(w, pc[:n])
+		}, nil, nil)
+	}
+	switch action {
+	case PanicRepanic:
+		panic(v)
+	case PanicExit:
+		_ = l.Close()
+		os.Exit(2)
+	}
+}
+
+// writeIndented writes the text with each line indented.
+func writeIndented(w io.Writer, txt []byte) error {
+	var buf [256]byte
+	for len(txt) > 0 {
+		line := txt
+		if i := bytes.IndexByte(txt, '\n'); i >= 0 {
+			line, txt = txt[:i], txt[i+1:]
+		} else {
+			txt = nil
+		}
+		b := append(append(buf[:0], indent...), line...)
+		b = append(b, '\n')
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allStacks returns the stack traces of all goroutines.
+func allStacks() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
 }
@@ -0,0 +1,130 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+const defaultRetryInterval = 10 * time.Second
+
+// ErrorPolicy specifies how a logger handles errors from its output.
+type ErrorPolicy struct {
+	// Fallback is an output spec as accepted by SetOutputSpec, e.g. "stderr".
+	// While the output is failing, messages are written to the fallback instead.
+	// Empty means no fallback, so the messages are lost.
+	Fallback string
+	// RetryInterval is the minimum interval between attempts to use a failing output again,
+	// 10 seconds by default. Before each attempt, syslog and file outputs reconnect
+	// or reopen their files.
+	RetryInterval time.Duration
+	// Quiet disables reporting the first failure and the recovery to stderr.
+	Quiet bool
+	// OnError is called with each error from the output. It is called with the output locked,
+	// so it must not write to the same logger.
+	OnError func(err error)
+}
+
+// reconnecter is implemented by outputs that can reconnect or reopen their files after errors.
+type reconnecter interface {
+	reconnect() error
+}
+
+type failover struct {
+	ErrorPolicy
+	fallback output
+	failing  bool
+	retryAt  time.Time
+	err      error
+}
+
+// SetErrorPolicy sets how output errors are handled. A nil policy restores the default,
+// which only returns errors to the caller. On error, the current policy is kept.
+func (l *Logger) SetErrorPolicy(p *ErrorPolicy) error {
+	var f *failover
+	if p != nil {
+		f = &failover{ErrorPolicy: *p}
+		if f.RetryInterval <= 0 {
+			f.RetryInterval = defaultRetryInterval
+		}
+		if p.Fallback != "" {
+			w, err := parseOutputSpec(p.Fallback)
+			if err != nil {
+				return err
+			}
+			f.fallback = w
+		}
+	}
+	l.outMu.Lock()
+	old := l.failover
+	l.failover = f
+	l.outMu.Unlock()
+	if old != nil {
+		if c, ok := old.fallback.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
+	return nil
+}
+
+// write writes the message to the output, or to the fallback while the output is failing.
+// It is called with the output locked.
+func (f *failover) write(c *core, m *message) error {
+	now := time.Now()
+	if f.failing {
+		if now.Before(f.retryAt) {
+			return f.writeFallback(m)
+		}
+		if r, ok := c.out.(reconnecter); ok {
+			if err := r.reconnect(); err != nil {
+				f.fail(err, now)
+				return f.writeFallback(m)
+			}
+		}
+	}
+	if err := c.out.WriteMsg(m); err != nil {
+		f.fail(err, now)
+		return f.writeFallback(m)
+	}
+	if f.failing {
+		f.failing = false
+		if !f.Quiet {
+			fmt.Fprintln(os.Stderr, "slog: output recovered")
+		}
+	}
+	return nil
+}
+
+func (f *failover) fail(err error, now time.Time) {
+	f.err = err
+	f.retryAt = now.Add(f.RetryInterval)
+	if !f.failing {
+		f.failing = true
+		if !f.Quiet {
+			fallback := "discarding messages"
+			if f.Fallback != "" {
+				fallback = "writing to " + f.Fallback
+			}
+			fmt.Fprintf(os.Stderr, "slog: output error, %s: %v\n", fallback, err)
+		}
+	}
+	if f.OnError != nil {
+		f.OnError(err)
+	}
+}
+
+func (f *failover) writeFallback(m *message) error {
+	if f.fallback == nil {
+		return f.err
+	}
+	return f.fallback.WriteMsg(m)
+}
+
+// reset forgets the failure of a replaced output.
+func (f *failover) reset() {
+	f.failing, f.err = false, nil
+}
@@ -0,0 +1,126 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+// flakyWriter fails all writes while broken.
+type flakyWriter struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	broken   bool
+	attempts int
+}
+
+func (w *flakyWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.attempts++
+	if w.broken {
+		return 0, errors.New("broken pipe")
+	}
+	return w.buf.Write(p)
+}
+
+func (w *flakyWriter) set(broken bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.broken = broken
+}
+
+func TestErrorPolicyFallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fallback.log")
+	w := &flakyWriter{broken: true}
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputWriter, w); err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	if err := logger.SetErrorPolicy(&slog.ErrorPolicy{
+		Fallback:      "file:" + path,
+		RetryInterval: 100 * time.Millisecond,
+		Quiet:         true,
+		OnError:       func(err error) { errs = append(errs, err) },
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetErrorPolicy(nil)
+
+	if err := logger.Log(0, slog.LevelInfo, nil, "first"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	logger.Info("second")
+	w.set(false)
+	logger.Info("third")
+	if w.attempts != 1 {
+		t.Errorf("the failing output was retried too early: %d attempts", w.attempts)
+	}
+	time.Sleep(150 * time.Millisecond)
+	logger.Info("fourth")
+
+	if out := w.buf.String(); strings.Count(out, "\n") != 1 || !strings.Contains(out, "fourth") {
+		t.Errorf("unexpected output: %q", out)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := string(b); strings.Count(out, "\n") != 3 || !strings.Contains(out, "first") || !strings.Contains(out, "third") {
+		t.Errorf("unexpected fallback output: %q", out)
+	}
+	if len(errs) != 1 || errs[0].Error() != "broken pipe" {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if m := logger.Metrics(); m.WriteErrors != 0 || m.Messages[slog.LevelInfo] != 4 {
+		t.Errorf("unexpected counters: %+v", m)
+	}
+}
+
+func TestErrorPolicyNoFallback(t *testing.T) {
+	w := &flakyWriter{broken: true}
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputWriter, w); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.SetErrorPolicy(&slog.ErrorPolicy{RetryInterval: time.Hour, Quiet: true}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := logger.Log(0, slog.LevelInfo, nil, "lost"); err == nil {
+			t.Error("expected an error")
+		}
+	}
+	if w.attempts != 1 {
+		t.Errorf("unexpected attempts: %d", w.attempts)
+	}
+
+	/* a new output is used right away */
+	w2 := &flakyWriter{}
+	if err := logger.SetOutput(slog.OutputWriter, w2); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Log(0, slog.LevelInfo, nil, "written"); err != nil || w2.attempts != 1 {
+		t.Errorf("unexpected result: %v, %d attempts", err, w2.attempts)
+	}
+	if m := logger.Metrics(); m.WriteErrors != 3 || m.Messages[slog.LevelInfo] != 1 {
+		t.Errorf("unexpected counters: %+v", m)
+	}
+
+	if err := logger.SetErrorPolicy(&slog.ErrorPolicy{Fallback: "nowhere"}); err == nil {
+		t.Error("expected an error for an invalid fallback")
+	}
+}
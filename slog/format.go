@@ -0,0 +1,200 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFormat is the line layout of text and terminal outputs unless changed with SetFormat.
+const DefaultFormat = "%l %t %F:%n %m%a"
+
+var startTime = time.Now()
+
+type formatItem struct {
+	verb byte // 0 for literal text
+	lit  string
+}
+
+// lineFormat is a parsed line layout with the terminal colors for each level.
+type lineFormat struct {
+	items     []formatItem
+	colors    [len(levelColor)]string
+	goroutine bool
+}
+
+var defaultFormat = func() *lineFormat {
+	f, err := parseFormat(DefaultFormat)
+	if err != nil {
+		panic(err)
+	}
+	f.colors = levelColor
+	return f
+}()
+
+func parseFormat(layout string) (*lineFormat, error) {
+	f := &lineFormat{}
+	var lit []byte
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		if c != '%' {
+			lit = append(lit, c)
+			continue
+		}
+		i++
+		if i >= len(layout) {
+			return nil, fmt.Errorf("slog: incomplete verb in format %q", layout)
+		}
+		switch verb := layout[i]; verb {
+		case '%':
+			lit = append(lit, '%')
+		case 'l', 'L', 't', 'e', 'F', 'f', 'n', 's', 'g', 'm', 'a':
+			if len(lit) > 0 {
+				f.items = append(f.items, formatItem{lit: string(lit)})
+				lit = lit[:0]
+			}
+			f.items = append(f.items, formatItem{verb: verb})
+			if verb == 'g' {
+				f.goroutine = true
+			}
+		default:
+			return nil, fmt.Errorf("slog: unknown verb %%%c in format %q", verb, layout)
+		}
+	}
+	if len(lit) > 0 {
+		f.items = append(f.items, formatItem{lit: string(lit)})
+	}
+	return f, nil
+}
+
+// SetFormat sets the line layout of text and terminal outputs. The layout is
+// literal text with the following verbs:
+//
+//	%l  level letter, e.g. "W"
+//	%L  level name, e.g. "warning"
+//	%t  timestamp, as specified by the flags
+//	%e  seconds elapsed since the program started
+//	%F  source file path
+//	%f  source file name
+//	%n  source line number
+//	%s  function name
+//	%g  goroutine ID
+//	%m  message
+//	%a  attributes, each preceded by a space
+//	%%  a literal "%"
+//
+// For example, "%l %f:%n %m%a" omits the timestamp for journald, which adds its own.
+// An empty layout restores DefaultFormat.
+func (l *Logger) SetFormat(layout string) error {
+	if layout == "" {
+		layout = DefaultFormat
+	}
+	f, err := parseFormat(layout)
+	if err != nil {
+		return err
+	}
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
+	f.colors = l.lineFormat().colors
+	l.format.Store(f)
+	return nil
+}
+
+// SetLevelColor sets the SGR parameters used by terminal outputs for the level,
+// e.g. "1;31" for bold red. An empty string disables coloring for the level.
+func (l *Logger) SetLevelColor(level Level, sgr string) {
+	if level < LevelSilence || level > LevelVeryVerbose {
+		return
+	}
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
+	f := *l.lineFormat()
+	f.colors[level] = ";" + sgr
+	l.format.Store(&f)
+}
+
+func (c *core) lineFormat() *lineFormat {
+	if f := c.format.Load(); f != nil {
+		return f
+	}
+	return defaultFormat
+}
+
+// goroutineID parses the ID of the current goroutine from its stack trace header.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+func appendFuncName(b []byte, pc uintptr) []byte {
+	if pc == 0 {
+		return append(b, "???"...)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.Function == "" {
+		return append(b, "???"...)
+	}
+	name := frame.Function
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return append(b, name...)
+}
+
+// appendLine renders the message without the trailing newline.
+func (f *lineFormat) appendLine(b []byte, m *message, term bool) []byte {
+	for _, item := range f.items {
+		switch item.verb {
+		case 0:
+			b = append(b, item.lit...)
+		case 'l':
+			b = append(b, levelChar[m.level])
+		case 'L':
+			b = append(b, levelName[m.level]...)
+		case 't':
+			b = appendTimestamp(b, m.timestamp, m.flags)
+		case 'e':
+			b = strconv.AppendFloat(b, m.timestamp.Sub(startTime).Seconds(), 'f', 6, 64)
+		case 'F':
+			file, _ := m.caller()
+			b = append(b, file...)
+		case 'f':
+			file, _ := m.caller()
+			b = append(b, path.Base(file)...)
+		case 'n':
+			_, line := m.caller()
+			b = strconv.AppendInt(b, int64(line), 10)
+		case 's':
+			b = appendFuncName(b, m.pc)
+		case 'g':
+			b = strconv.AppendUint(b, m.goid, 10)
+		case 'm':
+			b = append(b, m.text...)
+		case 'a':
+			if !term {
+				b = appendAttrs(b, m.attrs)
+				break
+			}
+			for _, a := range m.attrs {
+				b = append(b, " \x1b[2m"...) // dim
+				b = append(b, a.Key...)
+				b = append(b, "=\x1b[22m"...)
+				b = appendQuotedValue(b, a)
+			}
+		}
+	}
+	return b
+}
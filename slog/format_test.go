@@ -0,0 +1,72 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestSetFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelInfo)
+
+	tests := []struct {
+		layout string
+		re     string
+	}{
+		{"%l %f:%n %m%a", `^W format_test\.go:\d+ hello key=value\n$`},
+		{"[%L] %m (%s)", `^\[warning\] hello \(slog_test\.TestSetFormat\)\n$`},
+		{"%e g%g 100%% %m", `^\d+\.\d{6} g\d+ 100% hello\n$`},
+		{"", `^W \d{4}-\d\d-\d\dT\S+ \S+/format_test\.go:\d+ hello key=value\n$`},
+	}
+	for _, tt := range tests {
+		if err := logger.SetFormat(tt.layout); err != nil {
+			t.Fatalf("SetFormat(%q): %v", tt.layout, err)
+		}
+		buf.Reset()
+		logger.Warningw("hello", slog.String("key", "value"))
+		if !regexp.MustCompile(tt.re).Match(buf.Bytes()) {
+			t.Errorf("SetFormat(%q): unexpected output %q", tt.layout, buf.String())
+		}
+	}
+
+	for _, layout := range []string{"%", "%m %x"} {
+		if err := logger.SetFormat(layout); err == nil {
+			t.Errorf("SetFormat(%q): expected error", layout)
+		}
+	}
+}
+
+func TestSetLevelColor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputTerminal, &buf)
+	logger.SetLevel(slog.LevelInfo)
+	logger.SetLevelColor(slog.LevelInfo, "1;34")
+	if err := logger.SetFormat("%l %m%a"); err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Infow("hello", slog.Int("n", 1))
+	logger.Error("failed")
+	expected := "\x1b[;1;34mI hello \x1b[2mn=\x1b[22m1\x1b[0m\n" +
+		"\x1b[;91mE failed\x1b[0m\n"
+	if output := buf.String(); output != expected {
+		t.Errorf("unexpected output %q", output)
+	}
+
+	buf.Reset()
+	logger.SetLevelColor(slog.LevelError, "")
+	logger.Error("plain")
+	if output := buf.String(); !strings.HasPrefix(output, "\x1b[;mE plain") {
+		t.Errorf("unexpected output %q", output)
+	}
+}
@@ -0,0 +1,122 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build go1.21
+
+package slog
+
+import (
+	"context"
+	stdslog "log/slog"
+	"time"
+)
+
+// Handler is a log/slog.Handler that writes records to a Logger.
+type Handler struct {
+	l     *Logger
+	attrs []Attr
+	group string
+}
+
+var _ stdslog.Handler = (*Handler)(nil)
+
+// NewHandler returns a log/slog.Handler backed by the given Logger.
+func NewHandler(l *Logger) *Handler {
+	return &Handler{l: l}
+}
+
+// FromStdLevel maps a log/slog level onto the nearest Level.
+func FromStdLevel(level stdslog.Level) Level {
+	switch {
+	case level >= stdslog.LevelError:
+		return LevelError
+	case level >= stdslog.LevelWarn:
+		return LevelWarning
+	case level > stdslog.LevelInfo:
+		return LevelNotice
+	case level >= stdslog.LevelInfo:
+		return LevelInfo
+	case level >= stdslog.LevelDebug:
+		return LevelDebug
+	case level >= stdslog.LevelDebug-4:
+		return LevelVerbose
+	}
+	return LevelVeryVerbose
+}
+
+// Enabled implements log/slog.Handler.
+func (h *Handler) Enabled(_ context.Context, level stdslog.Level) bool {
+	return h.l.CheckLevel(FromStdLevel(level))
+}
+
+// Handle implements log/slog.Handler.
+func (h *Handler) Handle(_ context.Context, r stdslog.Record) error {
+	now := r.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	attrs := make([]Attr, 0, len(h.attrs)+r.NumAttrs())
+	attrs = append(attrs, h.attrs...)
+	r.Attrs(func(a stdslog.Attr) bool {
+		attrs = appendStdAttr(attrs, h.group, a)
+		return true
+	})
+	return h.l.write(now, FromStdLevel(r.Level), r.PC, msgString, r.Message, nil, nil, nil, attrs)
+}
+
+// WithAttrs implements log/slog.Handler.
+func (h *Handler) WithAttrs(attrs []stdslog.Attr) stdslog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = h.attrs[:len(h.attrs):len(h.attrs)]
+	for _, a := range attrs {
+		h2.attrs = appendStdAttr(h2.attrs, h.group, a)
+	}
+	return &h2
+}
+
+// WithGroup implements log/slog.Handler. Keys of grouped attributes are qualified with dots.
+func (h *Handler) WithGroup(name string) stdslog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group = h.group + name + "."
+	return &h2
+}
+
+func appendStdAttr(attrs []Attr, group string, a stdslog.Attr) []Attr {
+	v := a.Value.Resolve()
+	if v.Kind() == stdslog.KindGroup {
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			attrs = appendStdAttr(attrs, group, ga)
+		}
+		return attrs
+	}
+	if a.Key == "" {
+		return attrs
+	}
+	key := group + a.Key
+	switch v.Kind() {
+	case stdslog.KindString:
+		return append(attrs, String(key, v.String()))
+	case stdslog.KindInt64:
+		return append(attrs, Int64(key, v.Int64()))
+	case stdslog.KindUint64:
+		return append(attrs, Uint64(key, v.Uint64()))
+	case stdslog.KindFloat64:
+		return append(attrs, Float64(key, v.Float64()))
+	case stdslog.KindBool:
+		return append(attrs, Bool(key, v.Bool()))
+	case stdslog.KindDuration:
+		return append(attrs, Duration(key, v.Duration()))
+	case stdslog.KindTime:
+		return append(attrs, Time(key, v.Time()))
+	}
+	return append(attrs, Any(key, v.Any()))
+}
@@ -0,0 +1,61 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build go1.21
+
+package slog_test
+
+import (
+	"bytes"
+	stdslog "log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestFromStdLevel(t *testing.T) {
+	tests := []struct {
+		std   stdslog.Level
+		level slog.Level
+	}{
+		{stdslog.LevelError + 4, slog.LevelError},
+		{stdslog.LevelError, slog.LevelError},
+		{stdslog.LevelWarn, slog.LevelWarning},
+		{stdslog.LevelInfo + 2, slog.LevelNotice},
+		{stdslog.LevelInfo, slog.LevelInfo},
+		{stdslog.LevelDebug, slog.LevelDebug},
+		{stdslog.LevelDebug - 4, slog.LevelVerbose},
+		{stdslog.LevelDebug - 8, slog.LevelVeryVerbose},
+	}
+	for _, tt := range tests {
+		if got := slog.FromStdLevel(tt.std); got != tt.level {
+			t.Errorf("FromStdLevel(%v): expected %d, got %d", tt.std, tt.level, got)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelInfo)
+
+	std := stdslog.New(slog.NewHandler(logger))
+	std.Debug("filtered")
+	if buf.Len() != 0 {
+		t.Errorf("expected debug record to be filtered, got: %s", buf.String())
+	}
+
+	std.With("conn", 3).WithGroup("req").Warn("slow", "path", "/", stdslog.Group("peer", "addr", "1.2.3.4"))
+	output := buf.String()
+	if !strings.HasPrefix(output, "W ") {
+		t.Errorf("expected warning prefix, got: %s", output)
+	}
+	if !strings.Contains(output, "handler_test.go:") {
+		t.Errorf("expected caller file from record, got: %s", output)
+	}
+	if !strings.Contains(output, "slow conn=3 req.path=/ req.peer.addr=1.2.3.4\n") {
+		t.Errorf("expected qualified attributes, got: %s", output)
+	}
+}
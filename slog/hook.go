@@ -0,0 +1,223 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+// Hook processes every message before it reaches the output. It may modify the entry,
+// e.g. to add attributes or redact the message, or return false to drop the message.
+// Hooks run in the goroutine that wrote the message, after level filtering, sampling
+// and rate limiting. They must be safe for concurrent use.
+type Hook interface {
+	Process(e *Entry) bool
+}
+
+// HookFunc is an adapter to use an ordinary function as a Hook.
+type HookFunc func(e *Entry) bool
+
+// Process calls f(e).
+func (f HookFunc) Process(e *Entry) bool {
+	return f(e)
+}
+
+// Entry is a message passed through hooks. It must not be retained after Process returns.
+// The source location and the extra payload are only resolved if a hook asks for them.
+type Entry struct {
+	m        *message
+	extra    []byte
+	extraErr error
+	rendered bool // extra holds the rendered payload
+	modified bool // extra was replaced by SetExtra
+	binary   []byte
+	mask     []bool
+}
+
+// Time returns the time of the message.
+func (e *Entry) Time() time.Time {
+	return e.m.timestamp
+}
+
+// Level returns the level of the message.
+func (e *Entry) Level() Level {
+	return e.m.level
+}
+
+// SetLevel changes the level of the message. Invalid levels are ignored.
+func (e *Entry) SetLevel(level Level) {
+	if level >= LevelSilence && level <= LevelVeryVerbose {
+		e.m.level = level
+	}
+}
+
+// Caller returns the source location of the message.
+func (e *Entry) Caller() (file string, line int) {
+	return e.m.caller()
+}
+
+// Message returns the message text, which may be modified in place.
+func (e *Entry) Message() []byte {
+	return e.m.text
+}
+
+// SetMessage replaces the message text.
+func (e *Entry) SetMessage(msg string) {
+	e.m.text = append(e.m.text[:0], msg...)
+}
+
+// Attrs returns the attributes of the message, which may be modified in place.
+func (e *Entry) Attrs() []Attr {
+	return e.m.attrs
+}
+
+// AddAttrs appends attributes to the message.
+func (e *Entry) AddAttrs(attrs ...Attr) {
+	e.m.attrs = append(e.m.attrs, attrs...)
+}
+
+// SetAttrs replaces the attributes of the message. attrs may be a subslice of Attrs.
+func (e *Entry) SetAttrs(attrs []Attr) {
+	n := len(e.m.attrs)
+	e.m.attrs = append(e.m.attrs[:0], attrs...)
+	for i := len(e.m.attrs); i < n; i++ {
+		e.m.attrs[:n][i] = Attr{}
+	}
+}
+
+// Extra returns the extra payload, e.g. the text of Text or the hex dump of Binary,
+// rendered on first use, and the error from rendering it.
+func (e *Entry) Extra() ([]byte, error) {
+	if !e.rendered {
+		e.rendered = true
+		switch {
+		case e.binary != nil:
+			var buf bytes.Buffer
+			e.extraErr = writeBinary(&buf, e.binary, e.mask, e.m.binary.wrap)
+			e.extra = buf.Bytes()
+		case e.m.writeExtra != nil:
+			var buf bytes.Buffer
+			e.extraErr = e.m.writeExtra(&buf)
+			e.extra = buf.Bytes()
+		}
+	}
+	return e.extra, e.extraErr
+}
+
+// SetExtra replaces the extra payload. For messages from Binary, the data is dropped.
+// An error from rendering the previous payload is still returned to the caller.
+func (e *Entry) SetExtra(extra []byte) {
+	e.extra = extra
+	e.rendered, e.modified = true, true
+	e.binary, e.mask = nil, nil
+}
+
+// Binary returns a copy of the data logged by Binary or Binaryf, or nil for other messages.
+// Changes to the copy are reflected in the hex dump.
+func (e *Entry) Binary() []byte {
+	if e.binary == nil && e.m.binary != nil && !e.modified {
+		e.binary = append([]byte(nil), e.m.binary.data...)
+		e.mask = append([]bool(nil), e.m.binary.mask...)
+		e.rendered = false
+	}
+	return e.binary
+}
+
+// MaskBinary zeroes n bytes of the binary data at off and marks them in the hex dump.
+func (e *Entry) MaskBinary(off, n int) {
+	bin := e.Binary()
+	if off < 0 || n <= 0 || off >= len(bin) {
+		return
+	}
+	if n > len(bin)-off {
+		n = len(bin) - off
+	}
+	if e.mask == nil {
+		e.mask = make([]bool, len(bin))
+	}
+	for i := off; i < off+n; i++ {
+		bin[i], e.mask[i] = 0, true
+	}
+	e.rendered = false
+}
+
+// apply writes the changes to the extra payload back to the message.
+func (e *Entry) apply() {
+	m := e.m
+	switch {
+	case e.binary != nil:
+		m.binary = &binaryDump{e.binary, m.binary.wrap, e.mask}
+		m.writeExtra = m.binary.write
+	case e.rendered:
+		m.binary = nil
+		m.writeExtra = nil
+		if extra, extraErr := e.extra, e.extraErr; len(extra) > 0 || extraErr != nil {
+			m.writeExtra = func(w io.Writer) error {
+				if _, err := w.Write(extra); err != nil {
+					return err
+				}
+				return extraErr
+			}
+		}
+	}
+}
+
+// AttrsHook returns a Hook appending the given attributes to every message,
+// e.g. the host name and the process ID.
+func AttrsHook(attrs ...Attr) Hook {
+	return HookFunc(func(e *Entry) bool {
+		e.AddAttrs(attrs...)
+		return true
+	})
+}
+
+// GoroutineHook returns a Hook appending the ID of the writing goroutine as attribute "goroutine".
+func GoroutineHook() Hook {
+	return HookFunc(func(e *Entry) bool {
+		e.AddAttrs(Uint64("goroutine", goroutineID()))
+		return true
+	})
+}
+
+// AddHook appends a hook to the logger. Hooks run in the order they were added.
+// The hooks are shared with all loggers derived from this one.
+func (l *Logger) AddHook(h Hook) {
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
+	var hooks []Hook
+	if p := l.hooks.Load(); p != nil {
+		hooks = append(hooks, *p...)
+	}
+	hooks = append(hooks, h)
+	l.hooks.Store(&hooks)
+}
+
+// SetHooks replaces all hooks of the logger. No arguments removes all hooks.
+func (l *Logger) SetHooks(hooks ...Hook) {
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
+	if len(hooks) == 0 {
+		l.hooks.Store(nil)
+		return
+	}
+	hooks = append([]Hook(nil), hooks...)
+	l.hooks.Store(&hooks)
+}
+
+// runHooks passes the message through the hooks and reports whether it is kept.
+// The entry lives in the pooled message, so that hooks not resolving anything cost no allocations.
+func runHooks(hooks []Hook, m *message) bool {
+	e := &m.entry
+	*e = Entry{m: m}
+	defer func() { *e = Entry{} }()
+	for _, h := range hooks {
+		if !h.Process(e) {
+			return false
+		}
+	}
+	e.apply()
+	return true
+}
@@ -0,0 +1,147 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestHooks(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+	logger.AddHook(slog.AttrsHook(slog.Int("pid", 42)))
+	logger.AddHook(slog.HookFunc(func(e *slog.Entry) bool {
+		if bytes.HasPrefix(e.Message(), []byte("drop")) {
+			return false
+		}
+		e.SetMessage(strings.ReplaceAll(string(e.Message()), "hunter2", "***"))
+		if extra, err := e.Extra(); err != nil {
+			t.Error(err)
+		} else if extra != nil {
+			e.SetExtra(bytes.ReplaceAll(extra, []byte("hunter2"), []byte("***")))
+		}
+		return true
+	}))
+
+	logger.With(slog.String("user", "alice")).Infof("password is %s", "hunter2")
+	logger.Info("drop me")
+	logger.Println(0, slog.LevelInfo, func(w io.Writer) error {
+		_, err := io.WriteString(w, "token=hunter2\n")
+		return err
+	}, "payload")
+
+	records := c.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	r := records[0]
+	if r.Message != "password is ***" {
+		t.Errorf("unexpected message: %q", r.Message)
+	}
+	if v, ok := r.Attr("user"); !ok || v != "alice" {
+		t.Errorf("unexpected attribute user: %v", v)
+	}
+	if v, ok := r.Attr("pid"); !ok || v != int64(42) {
+		t.Errorf("unexpected attribute pid: %v", v)
+	}
+	if !strings.HasSuffix(r.File, "hook_test.go") {
+		t.Errorf("unexpected source file: %s", r.File)
+	}
+	if extra := string(records[1].Extra); strings.Contains(extra, "hunter2") || !strings.Contains(extra, "token=***") {
+		t.Errorf("unexpected extra payload: %q", extra)
+	}
+
+	logger.SetHooks()
+	logger.Info("drop me")
+	if len(c.Records()) != 3 {
+		t.Error("hooks were not removed")
+	}
+}
+
+func TestHookText(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputWriter, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.SetFormat("%l %m%a"); err != nil {
+		t.Fatal(err)
+	}
+	logger.SetHooks(slog.GoroutineHook(), slog.HookFunc(func(e *slog.Entry) bool {
+		e.SetLevel(slog.LevelWarning)
+		extra, _ := e.Extra()
+		e.SetExtra(append(extra, "appended\n"...))
+		return true
+	}))
+	logger.Info("hello")
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "W hello goroutine=") || !strings.HasSuffix(out, "\nappended\n") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestHookEntry(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+	errPayload := errors.New("payload error")
+	logger.SetHooks(slog.HookFunc(func(e *slog.Entry) bool {
+		if file, line := e.Caller(); !strings.HasSuffix(file, "hook_test.go") || line == 0 {
+			t.Errorf("unexpected caller: %s:%d", file, line)
+		}
+		/* drop attributes with empty values */
+		attrs := e.Attrs()[:0]
+		for _, a := range e.Attrs() {
+			if a.Value() != "" {
+				attrs = append(attrs, a)
+			}
+		}
+		e.SetAttrs(attrs)
+		if extra, err := e.Extra(); err != nil && !bytes.Equal(extra, []byte("partial\n")) {
+			t.Errorf("unexpected extra payload: %q", extra)
+		}
+		return true
+	}))
+
+	logger.Infow("attrs", slog.String("a", ""), slog.String("b", "1"), slog.String("c", ""))
+	err := logger.Println(0, slog.LevelInfo, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial\n")
+		return errPayload
+	}, "failing")
+	if !errors.Is(err, errPayload) {
+		t.Errorf("expected the payload error, got %v", err)
+	}
+
+	records := c.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if attrs := records[0].Attrs; len(attrs) != 1 || attrs[0].Key != "b" {
+		t.Errorf("unexpected attributes: %+v", attrs)
+	}
+	if string(records[1].Extra) != "partial\n" {
+		t.Errorf("unexpected extra payload: %q", records[1].Extra)
+	}
+}
+
+func BenchmarkHooks(b *testing.B) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	logger.SetOutput(slog.OutputWriter, io.Discard)
+	logger.AddHook(slog.AttrsHook(slog.String("host", "example"), slog.Int("pid", 42)))
+	logger.AddHook(slog.NewRedactor(nil))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infow("hello", slog.String("user", "alice"))
+	}
+}
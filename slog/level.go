@@ -0,0 +1,172 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var levelAlias = map[string]Level{
+	"-":    LevelSilence,
+	"f":    LevelFatal,
+	"e":    LevelError,
+	"err":  LevelError,
+	"w":    LevelWarning,
+	"warn": LevelWarning,
+	"i":    LevelInfo,
+	"d":    LevelDebug,
+	"v":    LevelVerbose,
+}
+
+// String returns the name of the level, e.g. "warning".
+func (level Level) String() string {
+	if level >= LevelSilence && int(level) < len(levelName) {
+		return levelName[level]
+	}
+	return "Level(" + strconv.Itoa(int(level)) + ")"
+}
+
+// ParseLevel parses a level name like "warning" or "verbose", a single letter
+// as printed in text outputs like "W", or a number from 0 to 8.
+// Names are case-insensitive. "I" means LevelInfo and "V" means LevelVerbose.
+func ParseLevel(s string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for i, n := range levelName {
+		if name == n {
+			return Level(i), nil
+		}
+	}
+	if level, ok := levelAlias[name]; ok {
+		return level, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil && n >= int(LevelSilence) && n <= int(LevelVeryVerbose) {
+		return Level(n), nil
+	}
+	return LevelSilence, fmt.Errorf("slog: invalid level %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (level Level) MarshalText() ([]byte, error) {
+	return []byte(level.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (level *Level) UnmarshalText(text []byte) error {
+	l, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*level = l
+	return nil
+}
+
+var flagNames = [...]struct {
+	flag Flags
+	name string
+}{
+	{FlagUTC, "utc"},
+	{FlagNanos, "nanos"},
+}
+
+// String returns the comma-separated flag names, e.g. "utc,nanos", or "none".
+func (flags Flags) String() string {
+	var b []byte
+	for _, f := range flagNames {
+		if flags&f.flag != 0 {
+			if len(b) > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, f.name...)
+		}
+	}
+	if len(b) == 0 {
+		return "none"
+	}
+	return string(b)
+}
+
+// ParseFlags parses comma-separated flag names as returned by Flags.String.
+func ParseFlags(s string) (Flags, error) {
+	flags := FlagNone
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || name == "none" {
+			continue
+		}
+		found := false
+		for _, f := range flagNames {
+			if name == f.name {
+				flags |= f.flag
+				found = true
+				break
+			}
+		}
+		if !found {
+			return FlagNone, fmt.Errorf("slog: invalid flag %q", name)
+		}
+	}
+	return flags, nil
+}
+
+// Flags returns the current flags of the logger.
+func (l *Logger) Flags() Flags {
+	return Flags(l.flags.Load())
+}
+
+type levelHandler struct {
+	l *Logger
+}
+
+// LevelHandler returns an http.Handler that reports the logger level and flags as JSON on GET,
+// and changes them on POST or PUT with the form values "level" and "flags".
+func LevelHandler(l *Logger) http.Handler {
+	return &levelHandler{l: l}
+}
+
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost, http.MethodPut:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		level := h.l.Level()
+		if s := r.Form.Get("level"); s != "" {
+			var err error
+			if level, err = ParseLevel(s); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		flags := h.l.Flags()
+		if s, ok := r.Form["flags"]; ok {
+			var err error
+			if flags, err = ParseFlags(strings.Join(s, ",")); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		h.l.SetLevel(level)
+		h.l.SetFlags(flags)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	h.writeStatus(w)
+}
+
+func (h *levelHandler) writeStatus(w http.ResponseWriter) {
+	b := append([]byte(nil), `{"level":`...)
+	b = appendJSONString(b, []byte(h.l.Level().String()))
+	b = append(b, `,"flags":`...)
+	b = appendJSONString(b, []byte(h.l.Flags().String()))
+	b = append(b, "}\n"...)
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,122 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestParseLevel(t *testing.T) {
+	for level := slog.LevelSilence; level <= slog.LevelVeryVerbose; level++ {
+		parsed, err := slog.ParseLevel(level.String())
+		if err != nil || parsed != level {
+			t.Errorf("ParseLevel(%q): expected %d, got %d (%v)", level.String(), level, parsed, err)
+		}
+	}
+	tests := []struct {
+		s     string
+		level slog.Level
+	}{
+		{"WARNING", slog.LevelWarning},
+		{"warn", slog.LevelWarning},
+		{"W", slog.LevelWarning},
+		{"I", slog.LevelInfo},
+		{"V", slog.LevelVerbose},
+		{" debug ", slog.LevelDebug},
+		{"8", slog.LevelVeryVerbose},
+	}
+	for _, tt := range tests {
+		if level, err := slog.ParseLevel(tt.s); err != nil || level != tt.level {
+			t.Errorf("ParseLevel(%q): expected %d, got %d (%v)", tt.s, tt.level, level, err)
+		}
+	}
+	for _, s := range []string{"", "loud", "9", "-1"} {
+		if _, err := slog.ParseLevel(s); err == nil {
+			t.Errorf("ParseLevel(%q): expected error", s)
+		}
+	}
+	if s := slog.Level(42).String(); s != "Level(42)" {
+		t.Errorf("unexpected string for invalid level: %q", s)
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte("notice")); err != nil || level != slog.LevelNotice {
+		t.Errorf("UnmarshalText: expected %d, got %d (%v)", slog.LevelNotice, level, err)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		s     string
+		flags slog.Flags
+		str   string
+	}{
+		{"", slog.FlagNone, "none"},
+		{"none", slog.FlagNone, "none"},
+		{"utc", slog.FlagUTC, "utc"},
+		{"nanos, UTC", slog.FlagUTC | slog.FlagNanos, "utc,nanos"},
+	}
+	for _, tt := range tests {
+		flags, err := slog.ParseFlags(tt.s)
+		if err != nil || flags != tt.flags {
+			t.Errorf("ParseFlags(%q): expected %d, got %d (%v)", tt.s, tt.flags, flags, err)
+		}
+		if flags.String() != tt.str {
+			t.Errorf("%d.String(): expected %q, got %q", flags, tt.str, flags.String())
+		}
+	}
+	if _, err := slog.ParseFlags("utc,local"); err == nil {
+		t.Error("expected error for invalid flag")
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	h := slog.LevelHandler(logger)
+
+	get := func() map[string]string {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		var v map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+			t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+		}
+		return v
+	}
+	if v := get(); v["level"] != "info" || v["flags"] != "none" {
+		t.Errorf("unexpected status: %v", v)
+	}
+
+	form := url.Values{"level": {"verbose"}, "flags": {"utc"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"level":"verbose"`) {
+		t.Errorf("unexpected response: %d %s", rec.Code, rec.Body.String())
+	}
+	if logger.Level() != slog.LevelVerbose || logger.Flags() != slog.FlagUTC {
+		t.Errorf("unexpected logger state: %v %v", logger.Level(), logger.Flags())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/?level=loud", nil))
+	if rec.Code != http.StatusBadRequest || logger.Level() != slog.LevelVerbose {
+		t.Errorf("expected bad request, got %d, level %v", rec.Code, logger.Level())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected method not allowed, got %d", rec.Code)
+	}
+}
@@ -4,39 +4,99 @@
 package slog
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-// Logger represents a logger instance.
-type Logger struct {
+// core is the state shared by a logger and all loggers derived from it.
+type core struct {
 	out        output
+	failover   *failover // guarded by outMu
 	outMu      sync.Mutex
+	cfgMu      sync.Mutex
 	level      atomic.Int32
+	maxLevel   atomic.Int32
+	outLevel   atomic.Int32 // the most verbose level of outputs added with AddOutput
+	vmodule    atomic.Pointer[vmodule]
 	flags      atomic.Uint32
 	filePrefix atomic.Pointer[string]
+	rateLimit  atomic.Pointer[rateLimiter]
+	dedup      atomic.Pointer[deduper]
+	sampling   atomic.Pointer[sampling]
+	sampledOut atomic.Uint64
+	async      atomic.Pointer[asyncQueue]
+	format     atomic.Pointer[lineFormat]
+	hooks      atomic.Pointer[[]Hook]
+	dropped    atomic.Uint64
+	metrics    counters
+}
+
+// Logger represents a logger instance.
+type Logger struct {
+	*core
+	prefix string
+	attrs  []Attr
 }
 
 // NewLogger creates and returns a new Logger instance.
 func NewLogger() *Logger {
 	return &Logger{
-		out: newDiscardWriter(),
+		core: &core{
+			out: newDiscardWriter(),
+		},
 	}
 }
 
+// With returns a child logger that appends the given attributes to every message.
+// The child shares output, level, flags and file prefix with its parent,
+// so changing any of them on either logger affects both.
+func (l *Logger) With(attrs ...Attr) *Logger {
+	child := *l
+	child.attrs = append(l.attrs[:len(l.attrs):len(l.attrs)], attrs...)
+	return &child
+}
+
+// WithPrefix returns a child logger that prepends the given prefix to every message.
+// The child shares output, level, flags and file prefix with its parent.
+func (l *Logger) WithPrefix(prefix string) *Logger {
+	child := *l
+	child.prefix = l.prefix + prefix
+	return &child
+}
+
 type OutputType int
 
 const (
+	// OutputDiscard discards all messages.
 	OutputDiscard OutputType = iota
+	// OutputTerminal writes colored text to an io.Writer.
 	OutputTerminal
+	// OutputWriter writes plain text to an io.Writer.
 	OutputWriter
+	// OutputSyslog writes to the system logger with a tag string.
 	OutputSyslog
+	// OutputJSON writes one JSON object per line to an io.Writer.
+	// Attributes named like the fixed fields, e.g. "msg", are written as "attr.msg".
+	OutputJSON
+	// OutputFile writes plain text to a file path, with an optional *RotateConfig.
+	OutputFile
+	// OutputJournal writes to systemd-journald with a tag string and an optional socket path.
+	OutputJournal
+	// OutputRecorder keeps recent messages in a *Recorder.
+	OutputRecorder
+	// OutputCapture keeps all messages in a *Capture.
+	OutputCapture
+	// OutputTest writes plain text to the log of a test with a TB.
+	OutputTest
+	// OutputAuto writes to an io.Writer like OutputTerminal if ColorEnabled, or like OutputWriter otherwise.
+	OutputAuto
+	// OutputRemoteSyslog sends messages to a syslog server with a *RemoteSyslogConfig.
+	OutputRemoteSyslog
 )
 
 type Flags int
@@ -47,26 +107,133 @@ const (
 	FlagNanos       = 0x0002
 )
 
-// SetOutput sets the output type and parameters for the logger.
-func (l *Logger) SetOutput(t OutputType, v ...any) {
-	var w output
+// outputArg returns the i-th output parameter, or the zero value if an optional one is absent or nil.
+func outputArg[T any](t OutputType, v []any, i int, optional bool) (T, error) {
+	var zero T
+	if i >= len(v) || v[i] == nil {
+		if optional {
+			return zero, nil
+		}
+		return zero, fmt.Errorf("slog: missing parameter %d for output type %d", i, t)
+	}
+	arg, ok := v[i].(T)
+	if !ok {
+		return zero, fmt.Errorf("slog: invalid parameter %d for output type %d: %T", i, t, v[i])
+	}
+	return arg, nil
+}
+
+func newOutput(t OutputType, v ...any) (output, error) {
 	switch t {
 	case OutputDiscard:
-		w = newDiscardWriter()
-	case OutputTerminal:
-		w = newTermWriter(v[0].(io.Writer))
-	case OutputWriter:
-		w = newTextWriter(v[0].(io.Writer))
+		return newDiscardWriter(), nil
+	case OutputTerminal, OutputWriter, OutputJSON, OutputAuto:
+		out, err := outputArg[io.Writer](t, v, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		switch t {
+		case OutputTerminal:
+			return newTermWriter(out), nil
+		case OutputWriter:
+			return newTextWriter(out), nil
+		case OutputJSON:
+			return newJSONWriter(out), nil
+		}
+		return newAutoWriter(out), nil
 	case OutputSyslog:
+		tag, err := outputArg[string](t, v, 0, false)
+		if err != nil {
+			return nil, err
+		}
 		if newSyslogWriter == nil {
-			w = newDiscardWriter()
-		} else {
-			w = newSyslogWriter(v[0].(string))
+			return nil, errors.New("slog: syslog is not supported on this platform")
+		}
+		return newSyslogWriter(tag)
+	case OutputFile:
+		path, err := outputArg[string](t, v, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := outputArg[*RotateConfig](t, v, 1, true)
+		if err != nil {
+			return nil, err
+		}
+		return newFileWriter(path, cfg)
+	case OutputJournal:
+		tag, err := outputArg[string](t, v, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		path, err := outputArg[string](t, v, 1, true)
+		if err != nil {
+			return nil, err
+		}
+		if newJournalWriter == nil {
+			return nil, errors.New("slog: journal is not supported on this platform")
+		}
+		return newJournalWriter(tag, path)
+	case OutputRemoteSyslog:
+		cfg, err := outputArg[*RemoteSyslogConfig](t, v, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		return newRemoteSyslogWriter(cfg)
+	case OutputRecorder:
+		r, err := outputArg[*Recorder](t, v, 0, false)
+		if err != nil {
+			return nil, err
 		}
+		return &recorderWriter{r}, nil
+	case OutputCapture:
+		c, err := outputArg[*Capture](t, v, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		return &captureWriter{c}, nil
+	case OutputTest:
+		tb, err := outputArg[TB](t, v, 0, false)
+		if err != nil {
+			return nil, err
+		}
+		return newTestWriter(tb), nil
 	}
+	return nil, fmt.Errorf("slog: unknown output type %d", t)
+}
+
+// SetOutput sets the output type and parameters for the logger, replacing all existing outputs.
+// On error, the current output is kept.
+func (l *Logger) SetOutput(t OutputType, v ...any) error {
+	w, err := newOutput(t, v...)
+	if err != nil {
+		return err
+	}
+	l.setOutput(w)
+	return nil
+}
+
+func (l *Logger) setOutput(w output) {
 	l.outMu.Lock()
-	defer l.outMu.Unlock()
+	old := l.out
 	l.out = w
+	if l.failover != nil {
+		l.failover.reset()
+	}
+	l.setOutLevel(LevelSilence)
+	l.outMu.Unlock()
+	/* closing may block, e.g. on pending compression, so it is done without the lock */
+	if c, ok := old.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+// setOutLevel sets the most verbose level of outputs added with AddOutput.
+// It must be called with outMu held, which is always locked before cfgMu.
+func (c *core) setOutLevel(level Level) {
+	c.cfgMu.Lock()
+	defer c.cfgMu.Unlock()
+	c.outLevel.Store(int32(level))
+	c.updateMaxLevel()
 }
 
 // SetFlags sets the flags for the logger.
@@ -74,26 +241,119 @@ func (l *Logger) SetFlags(flags Flags) {
 	l.flags.Store(uint32(flags))
 }
 
-func (l *Logger) output(calldepth int, level Level, appendMsg func([]byte) []byte, writeExtra func(io.Writer) error) error {
+// msgKind specifies how the text of a message is rendered from the format and arguments.
+type msgKind uint8
+
+const (
+	msgString msgKind = iota // the format is the text
+	msgPrintf                // fmt.Appendf(format, args...)
+	msgPrint                 // AppendMsg(args...)
+
+	// msgUnleveled marks low-level calls, which are filtered by module levels only.
+	msgUnleveled msgKind = 1 << 7
+)
+
+func (l *Logger) output(calldepth int, level Level, kind msgKind, format string, args []any, writeExtra func(io.Writer) error, attrs []Attr) error {
 	now := time.Now()
-	_, file, line, ok := runtime.Caller(calldepth + 1)
-	if !ok {
-		file, line = "???", 0
-	} else if filePrefix := l.filePrefix.Load(); filePrefix != nil {
-		file = strings.TrimPrefix(file, *filePrefix)
+	var pc [1]uintptr
+	runtime.Callers(calldepth+2, pc[:])
+	return l.write(now, level, pc[0], kind, format, args, writeExtra, nil, attrs)
+}
+
+// write renders the message and sends it to the output. The source location is
+// resolved from pc only if needed, and none of the arguments are retained.
+func (l *Logger) write(now time.Time, level Level, pc uintptr, kind msgKind, format string, args []any, writeExtra func(io.Writer) error, bin *binaryDump, attrs []Attr) error {
+	effective := Level(-1)
+	if vm := l.vmodule.Load(); vm != nil {
+		effective = vm.levelFor(pc)
+	}
+	if effective < 0 && kind&msgUnleveled == 0 {
+		effective = l.Level()
+	}
+	/* outputs added with their own levels may still want the message */
+	secondary := effective >= 0 && level > effective
+	if secondary && level > Level(l.outLevel.Load()) {
+		return nil
+	}
+	kind &^= msgUnleveled
+	if s := l.sampling.Load(); s != nil && !s.sample(level) {
+		l.sampledOut.Add(1)
+		l.metrics.countDropped(level)
+		return nil
+	}
+	var suppressed uint64
+	if rl := l.rateLimit.Load(); rl != nil {
+		var ok bool
+		if ok, suppressed = rl.allow(pc, now); !ok {
+			l.metrics.countDropped(level)
+			return nil
+		}
 	}
 
-	l.outMu.Lock()
-	defer l.outMu.Unlock()
-	return l.out.WriteMsg(&message{
-		timestamp:  now,
-		flags:      Flags(l.flags.Load()),
-		level:      level,
-		file:       file,
-		line:       line,
-		appendMsg:  appendMsg,
-		writeExtra: writeExtra,
-	})
+	m := getMessage()
+	defer putMessage(m)
+	m.timestamp = now
+	m.flags = Flags(l.flags.Load())
+	m.level = level
+	m.secondary = secondary
+	m.pc = pc
+	m.filePrefix = l.filePrefix.Load()
+	m.text = append(m.text, l.prefix...)
+	switch kind {
+	case msgString:
+		m.text = append(m.text, format...)
+	case msgPrintf:
+		m.text = AppendMsgf(m.text, format, args...)
+	case msgPrint:
+		m.text = AppendMsg(m.text, args...)
+	}
+	m.writeExtra = writeExtra
+	if bin != nil {
+		m.binary, m.writeExtra = bin, bin.write
+	}
+	m.attrs = append(m.attrs, l.attrs...)
+	m.attrs = append(m.attrs, attrs...)
+	if suppressed > 0 {
+		m.attrs = append(m.attrs, Uint64("suppressed", suppressed))
+	}
+	if hooks := l.hooks.Load(); hooks != nil && !runHooks(*hooks, m) {
+		l.metrics.countDropped(level)
+		return nil
+	}
+	if m.format = l.format.Load(); m.format != nil && m.format.goroutine {
+		m.goid = goroutineID()
+	}
+	if d := l.dedup.Load(); d != nil {
+		return d.write(l.core, m)
+	}
+	return l.emit(m)
+}
+
+// emit writes the message to the asynchronous queue or directly to the output.
+func (c *core) emit(m *message) error {
+	if q := c.async.Load(); q != nil && q.enqueue(m) {
+		return nil
+	}
+	return c.writeMsg(m)
+}
+
+// writeMsg writes the message to the output and counts it.
+func (c *core) writeMsg(m *message) error {
+	c.outMu.Lock()
+	if _, ok := c.out.(*teeWriter); m.secondary && !ok {
+		/* the added outputs were replaced */
+		c.outMu.Unlock()
+		return nil
+	}
+	var err error
+	if f := c.failover; f != nil {
+		err = f.write(c, m)
+	} else {
+		err = c.out.WriteMsg(m)
+	}
+	c.outMu.Unlock()
+	c.metrics.countWritten(m.level, err)
+	return err
 }
 
 // AppendMsgf appends a formatted message to the given byte slice.
@@ -101,35 +361,33 @@ func AppendMsgf(b []byte, format string, v ...any) []byte {
 	return fmt.Appendf(b, format, v...)
 }
 
-// AppendMsg appends a message to the given byte slice.
+// AppendMsg appends a message to the given byte slice, with spaces between the operands.
 func AppendMsg(b []byte, v ...any) []byte {
-	buf := bytes.NewBuffer(b)
-	for i, arg := range v {
-		if i > 0 {
-			buf.WriteByte(' ')
-		}
-		fmt.Fprint(buf, arg)
-	}
-	return buf.Bytes()
+	b = fmt.Appendln(b, v...)
+	return b[:len(b)-1]
 }
 
 // Printf is the low-level interface to write arbitrary log messages.
 func (l *Logger) Printf(calldepth int, level Level, extra func(io.Writer) error, format string, v ...any) error {
-	return l.output(calldepth+1, level, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, extra)
+	return l.output(calldepth+1, level, msgPrintf|msgUnleveled, format, v, extra, nil)
 }
 
 // Println is the low-level interface to write arbitary log messages.
 func (l *Logger) Println(calldepth int, level Level, extra func(io.Writer) error, v ...any) error {
-	return l.output(calldepth+1, level, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, extra)
+	return l.output(calldepth+1, level, msgPrint|msgUnleveled, "", v, extra, nil)
+}
+
+// Log is the low-level interface to write log messages with attributes.
+func (l *Logger) Log(calldepth int, level Level, extra func(io.Writer) error, msg string, attrs ...Attr) error {
+	return l.output(calldepth+1, level, msgString|msgUnleveled, msg, nil, extra, attrs)
 }
 
 // SetLevel sets the logging level for the logger.
 func (l *Logger) SetLevel(level Level) {
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
 	l.level.Store(int32(level))
+	l.updateMaxLevel()
 }
 
 // Level returns the current logging level of the logger.
@@ -137,6 +395,11 @@ func (l *Logger) Level() Level {
 	return Level(l.level.Load())
 }
 
+// CheckLevel checks whether the given level may be enabled for any call site.
+func (l *Logger) CheckLevel(level Level) bool {
+	return level <= Level(l.maxLevel.Load())
+}
+
 // SetFilePrefix sets the file prefix to be stripped from file paths in log messages.
 func (l *Logger) SetFilePrefix(prefix string) {
 	l.filePrefix.Store(&prefix)
@@ -144,174 +407,202 @@ func (l *Logger) SetFilePrefix(prefix string) {
 
 // Temporaryf prints debug message regardless of log level.
 func (l *Logger) Temporaryf(format string, v ...any) {
-	l.output(1, LevelSilence, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelSilence, msgPrintf, format, v, nil, nil)
 }
 
 // Temporary prints debug message regardless of log level.
 func (l *Logger) Temporary(v ...any) {
-	l.output(1, LevelSilence, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelSilence, msgPrint, "", v, nil, nil)
 }
 
 // Fatalf logs serious problems that are likely to cause the program to exit.
 func (l *Logger) Fatalf(format string, v ...any) {
-	if LevelFatal > l.Level() {
+	if !l.CheckLevel(LevelFatal) {
 		return
 	}
-	l.output(1, LevelFatal, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelFatal, msgPrintf, format, v, nil, nil)
 }
 
 // Fatal logs serious problems that are likely to cause the program to exit.
 func (l *Logger) Fatal(v ...any) {
-	if LevelFatal > l.Level() {
+	if !l.CheckLevel(LevelFatal) {
 		return
 	}
-	l.output(1, LevelFatal, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelFatal, msgPrint, "", v, nil, nil)
+}
+
+// Fatalw logs serious problems that are likely to cause the program to exit, with attributes.
+func (l *Logger) Fatalw(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelFatal) {
+		return
+	}
+	l.output(1, LevelFatal, msgString, msg, nil, nil, attrs)
 }
 
 // Errorf logs issues that shouldn't be ignored.
 func (l *Logger) Errorf(format string, v ...any) {
-	if LevelError > l.Level() {
+	if !l.CheckLevel(LevelError) {
 		return
 	}
-	l.output(1, LevelError, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelError, msgPrintf, format, v, nil, nil)
 }
 
 // Error logs issues that shouldn't be ignored.
 func (l *Logger) Error(v ...any) {
-	if LevelError > l.Level() {
+	if !l.CheckLevel(LevelError) {
 		return
 	}
-	l.output(1, LevelError, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelError, msgPrint, "", v, nil, nil)
+}
+
+// Errorw logs issues that shouldn't be ignored, with attributes.
+func (l *Logger) Errorw(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelError) {
+		return
+	}
+	l.output(1, LevelError, msgString, msg, nil, nil, attrs)
 }
 
 // Warningf logs issues that may be ignored.
 func (l *Logger) Warningf(format string, v ...any) {
-	if LevelWarning > l.Level() {
+	if !l.CheckLevel(LevelWarning) {
 		return
 	}
-	l.output(1, LevelWarning, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelWarning, msgPrintf, format, v, nil, nil)
 }
 
 // Warning logs issues that may be ignored.
 func (l *Logger) Warning(v ...any) {
-	if LevelWarning > l.Level() {
+	if !l.CheckLevel(LevelWarning) {
 		return
 	}
-	l.output(1, LevelWarning, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelWarning, msgPrint, "", v, nil, nil)
+}
+
+// Warningw logs issues that may be ignored, with attributes.
+func (l *Logger) Warningw(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelWarning) {
+		return
+	}
+	l.output(1, LevelWarning, msgString, msg, nil, nil, attrs)
 }
 
 // Noticef logs important status changes. The prefix is 'I'.
 func (l *Logger) Noticef(format string, v ...any) {
-	if LevelNotice > l.Level() {
+	if !l.CheckLevel(LevelNotice) {
 		return
 	}
-	l.output(1, LevelNotice, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelNotice, msgPrintf, format, v, nil, nil)
 }
 
 // Notice logs important status changes. The prefix is 'I'.
 func (l *Logger) Notice(v ...any) {
-	if LevelNotice > l.Level() {
+	if !l.CheckLevel(LevelNotice) {
 		return
 	}
-	l.output(1, LevelNotice, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelNotice, msgPrint, "", v, nil, nil)
+}
+
+// Noticew logs important status changes with attributes. The prefix is 'I'.
+func (l *Logger) Noticew(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelNotice) {
+		return
+	}
+	l.output(1, LevelNotice, msgString, msg, nil, nil, attrs)
 }
 
 // Infof logs normal work reports.
 func (l *Logger) Infof(format string, v ...any) {
-	if LevelInfo > l.Level() {
+	if !l.CheckLevel(LevelInfo) {
 		return
 	}
-	l.output(1, LevelInfo, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelInfo, msgPrintf, format, v, nil, nil)
 }
 
 // Info logs normal work reports.
 func (l *Logger) Info(v ...any) {
-	if LevelInfo > l.Level() {
+	if !l.CheckLevel(LevelInfo) {
+		return
+	}
+	l.output(1, LevelInfo, msgPrint, "", v, nil, nil)
+}
+
+// Infow logs normal work reports, with attributes.
+func (l *Logger) Infow(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelInfo) {
 		return
 	}
-	l.output(1, LevelInfo, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelInfo, msgString, msg, nil, nil, attrs)
 }
 
 // Debugf logs extra information for debugging.
 func (l *Logger) Debugf(format string, v ...any) {
-	if LevelDebug > l.Level() {
+	if !l.CheckLevel(LevelDebug) {
 		return
 	}
-	l.output(1, LevelDebug, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelDebug, msgPrintf, format, v, nil, nil)
 }
 
 // Debug logs extra information for debugging.
 func (l *Logger) Debug(v ...any) {
-	if LevelDebug > l.Level() {
+	if !l.CheckLevel(LevelDebug) {
 		return
 	}
-	l.output(1, LevelDebug, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelDebug, msgPrint, "", v, nil, nil)
+}
+
+// Debugw logs extra information for debugging, with attributes.
+func (l *Logger) Debugw(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelDebug) {
+		return
+	}
+	l.output(1, LevelDebug, msgString, msg, nil, nil, attrs)
 }
 
 // Verbosef logs details for inspecting specific issues.
 func (l *Logger) Verbosef(format string, v ...any) {
-	if LevelVerbose > l.Level() {
+	if !l.CheckLevel(LevelVerbose) {
 		return
 	}
-	l.output(1, LevelVerbose, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelVerbose, msgPrintf, format, v, nil, nil)
 }
 
 // Verbose logs details for inspecting specific issues.
 func (l *Logger) Verbose(v ...any) {
-	if LevelVerbose > l.Level() {
+	if !l.CheckLevel(LevelVerbose) {
+		return
+	}
+	l.output(1, LevelVerbose, msgPrint, "", v, nil, nil)
+}
+
+// Verbosew logs details for inspecting specific issues, with attributes.
+func (l *Logger) Verbosew(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelVerbose) {
 		return
 	}
-	l.output(1, LevelVerbose, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelVerbose, msgString, msg, nil, nil, attrs)
 }
 
 // VeryVerbosef logs more details that may significantly impact performance. The prefix is 'V'.
 func (l *Logger) VeryVerbosef(format string, v ...any) {
-	if LevelVeryVerbose > l.Level() {
+	if !l.CheckLevel(LevelVeryVerbose) {
 		return
 	}
-	l.output(1, LevelVeryVerbose, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	l.output(1, LevelVeryVerbose, msgPrintf, format, v, nil, nil)
 }
 
 // VeryVerbose logs more details that may significantly impact performance. The prefix is 'V'.
 func (l *Logger) VeryVerbose(v ...any) {
-	if LevelVeryVerbose > l.Level() {
+	if !l.CheckLevel(LevelVeryVerbose) {
+		return
+	}
+	l.output(1, LevelVeryVerbose, msgPrint, "", v, nil, nil)
+}
+
+// VeryVerbosew logs more details with attributes. The prefix is 'V'.
+func (l *Logger) VeryVerbosew(msg string, attrs ...Attr) {
+	if !l.CheckLevel(LevelVeryVerbose) {
 		return
 	}
-	l.output(1, LevelVeryVerbose, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	l.output(1, LevelVeryVerbose, msgString, msg, nil, nil, attrs)
 }
@@ -0,0 +1,122 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+)
+
+// counters counts messages by level, shared by a logger and all loggers derived from it.
+type counters struct {
+	messages    [LevelVeryVerbose + 1]atomic.Uint64
+	dropped     [LevelVeryVerbose + 1]atomic.Uint64
+	writeErrors atomic.Uint64
+}
+
+func (c *counters) countDropped(level Level) {
+	if level >= LevelSilence && level <= LevelVeryVerbose {
+		c.dropped[level].Add(1)
+	}
+}
+
+func (c *counters) countWritten(level Level, err error) {
+	if err != nil {
+		c.writeErrors.Add(1)
+		return
+	}
+	if level >= LevelSilence && level <= LevelVeryVerbose {
+		c.messages[level].Add(1)
+	}
+}
+
+// Metrics is a snapshot of the message counters of a logger.
+type Metrics struct {
+	// Messages is the number of messages successfully written at each level,
+	// including to the fallback output of the ErrorPolicy if any.
+	Messages [LevelVeryVerbose + 1]uint64
+	// Dropped is the number of messages discarded at each level by sampling,
+	// rate limiting, hooks or a full asynchronous queue.
+	Dropped [LevelVeryVerbose + 1]uint64
+	// WriteErrors is the number of messages that failed to be written,
+	// including to the fallback output of the ErrorPolicy if any.
+	WriteErrors uint64
+}
+
+// Metrics returns the message counters of the logger, which are shared with all loggers derived from it.
+func (l *Logger) Metrics() Metrics {
+	var m Metrics
+	for level := range m.Messages {
+		m.Messages[level] = l.metrics.messages[level].Load()
+		m.Dropped[level] = l.metrics.dropped[level].Load()
+	}
+	m.WriteErrors = l.metrics.writeErrors.Load()
+	return m
+}
+
+func appendPrometheusCounter(b []byte, name, help string, values []uint64) []byte {
+	b = append(b, "# HELP "...)
+	b = append(b, name...)
+	b = append(b, ' ')
+	b = append(b, help...)
+	b = append(b, "\n# TYPE "...)
+	b = append(b, name...)
+	b = append(b, " counter\n"...)
+	for level, v := range values {
+		b = append(b, name...)
+		if len(values) > 1 {
+			b = append(b, `{level="`...)
+			b = append(b, levelName[level]...)
+			b = append(b, `"}`...)
+		}
+		b = append(b, ' ')
+		b = strconv.AppendUint(b, v, 10)
+		b = append(b, '\n')
+	}
+	return b
+}
+
+// AppendPrometheus appends the metrics in the Prometheus text exposition format,
+// with metric names starting with the given namespace, e.g. "myapp" for "myapp_log_messages_total".
+func (m *Metrics) AppendPrometheus(b []byte, namespace string) []byte {
+	prefix := "log_"
+	if namespace != "" {
+		prefix = namespace + "_log_"
+	}
+	b = appendPrometheusCounter(b, prefix+"messages_total", "Number of log messages successfully written by level.", m.Messages[:])
+	b = appendPrometheusCounter(b, prefix+"dropped_total", "Number of log messages dropped by level.", m.Dropped[:])
+	b = appendPrometheusCounter(b, prefix+"write_errors_total", "Number of failed log output writes.", []uint64{m.WriteErrors})
+	return b
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format,
+// as described in AppendPrometheus.
+func (m *Metrics) WritePrometheus(w io.Writer, namespace string) error {
+	_, err := w.Write(m.AppendPrometheus(nil, namespace))
+	return err
+}
+
+type metricsHandler struct {
+	l         *Logger
+	namespace string
+}
+
+// MetricsHandler returns an http.Handler that serves the logger metrics in the
+// Prometheus text exposition format, as described in Metrics.AppendPrometheus.
+func MetricsHandler(l *Logger, namespace string) http.Handler {
+	return &metricsHandler{l: l, namespace: namespace}
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	m := h.l.Metrics()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w, h.namespace)
+}
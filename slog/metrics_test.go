@@ -0,0 +1,88 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestMetrics(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+	logger.SetRateLimit(2, time.Hour)
+	logger.SetHooks(slog.HookFunc(func(e *slog.Entry) bool {
+		return string(e.Message()) != "secret"
+	}))
+
+	for i := 0; i < 5; i++ {
+		logger.Warning("repeated")
+	}
+	logger.With(slog.Int("n", 1)).Error("error")
+	logger.Info("secret")
+	logger.Debug("filtered")
+
+	m := logger.Metrics()
+	if m.Messages[slog.LevelWarning] != 2 || m.Dropped[slog.LevelWarning] != 3 {
+		t.Errorf("unexpected warning counters: %d written, %d dropped", m.Messages[slog.LevelWarning], m.Dropped[slog.LevelWarning])
+	}
+	if m.Messages[slog.LevelError] != 1 || m.Dropped[slog.LevelInfo] != 1 || m.Messages[slog.LevelDebug] != 0 {
+		t.Errorf("unexpected counters: %+v", m)
+	}
+	if n := len(c.Records()); n != 3 {
+		t.Errorf("expected 3 records, got %d", n)
+	}
+
+	logger.SetHooks()
+	logger.SetRateLimit(0, 0)
+	if err := logger.SetOutput(slog.OutputWriter, failingWriter{}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Error("lost")
+	if m := logger.Metrics(); m.WriteErrors != 1 || m.Messages[slog.LevelError] != 1 {
+		t.Errorf("unexpected counters: %+v", m)
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	logger.Errorf("error %d", 1)
+	logger.Info("info")
+
+	rec := httptest.NewRecorder()
+	slog.MetricsHandler(logger, "app").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type: %q", ct)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE app_log_messages_total counter\n",
+		`app_log_messages_total{level="error"} 1` + "\n",
+		`app_log_messages_total{level="info"} 1` + "\n",
+		`app_log_dropped_total{level="warning"} 0` + "\n",
+		"app_log_write_errors_total 0\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("missing %q in:\n%s", line, body)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	slog.MetricsHandler(logger, "").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status: %d", rec.Code)
+	}
+}
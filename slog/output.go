@@ -5,26 +5,132 @@ package slog
 
 import (
 	"io"
-	"strconv"
+	"runtime"
+	"strings"
+	"sync"
 	"time"
 )
 
-var newSyslogWriter func(string) output
+var newSyslogWriter func(string) (output, error)
+
+var newJournalWriter func(tag, path string) (output, error)
 
 type message struct {
 	timestamp  time.Time
 	level      Level
+	secondary  bool // only for outputs added with their own levels
 	flags      Flags
+	pc         uintptr
 	file       string
 	line       int
-	appendMsg  func([]byte) []byte
+	resolved   bool
+	filePrefix *string
+	text       []byte
 	writeExtra func(io.Writer) error
+	binary     *binaryDump
+	attrs      []Attr
+	format     *lineFormat
+	goid       uint64
+	entry      Entry // reused by hooks
+}
+
+// caller resolves the source location from the program counter on first use,
+// so that outputs not showing it never pay for the symbol lookup.
+func (m *message) caller() (file string, line int) {
+	if !m.resolved {
+		m.file, m.line = resolveCaller(m.pc)
+		if m.filePrefix != nil {
+			m.file = strings.TrimPrefix(m.file, *m.filePrefix)
+		}
+		m.resolved = true
+	}
+	return m.file, m.line
+}
+
+type callerInfo struct {
+	file string
+	line int
+}
+
+// callerCache maps program counters to source locations, which are finite in a program.
+var callerCache struct {
+	sync.RWMutex
+	m map[uintptr]callerInfo
+}
+
+func resolveCaller(pc uintptr) (file string, line int) {
+	if pc == 0 {
+		return "???", 0
+	}
+	callerCache.RLock()
+	ci, ok := callerCache.m[pc]
+	callerCache.RUnlock()
+	if ok {
+		return ci.file, ci.line
+	}
+	ci = callerInfo{"???", 0}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.File != "" {
+		ci = callerInfo{frame.File, frame.Line}
+	}
+	callerCache.Lock()
+	if callerCache.m == nil {
+		callerCache.m = make(map[uintptr]callerInfo)
+	}
+	callerCache.m[pc] = ci
+	callerCache.Unlock()
+	return ci.file, ci.line
+}
+
+const maxPooledSize = 64 * 1024
+
+var messagePool = sync.Pool{
+	New: func() any { return &message{} },
+}
+
+func getMessage() *message {
+	return messagePool.Get().(*message)
+}
+
+func putMessage(m *message) {
+	if cap(m.text) > maxPooledSize {
+		return
+	}
+	for i := range m.attrs {
+		m.attrs[i] = Attr{}
+	}
+	*m = message{text: m.text[:0], attrs: m.attrs[:0]}
+	messagePool.Put(m)
+}
+
+var bufPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, bufSize)
+		return &b
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufPool.Get().(*[]byte)
+}
+
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledSize {
+		return
+	}
+	*b = (*b)[:0]
+	bufPool.Put(b)
 }
 
 type output interface {
 	WriteMsg(m *message) error
 }
 
+// flusher is implemented by outputs that write messages in the background.
+type flusher interface {
+	flush() error
+}
+
 const bufSize = 4096
 
 type discardWriter struct{}
@@ -69,16 +175,15 @@ func appendTimestamp(b []byte, t time.Time, flags Flags) []byte {
 }
 
 func (w *textWriter) WriteMsg(m *message) error {
-	buf := make([]byte, 0, bufSize)
-	buf = append(buf, levelChar[m.level], ' ')
-	buf = appendTimestamp(buf, m.timestamp, m.flags)
-	buf = append(buf, ' ')
-	buf = append(buf, m.file...)
-	buf = append(buf, ':')
-	buf = strconv.AppendInt(buf, int64(m.line), 10)
-	buf = append(buf, ' ')
-	buf = m.appendMsg(buf)
+	f := m.format
+	if f == nil {
+		f = defaultFormat
+	}
+	b := getBuffer()
+	defer putBuffer(b)
+	buf := f.appendLine(*b, m, false)
 	buf = append(buf, '\n')
+	*b = buf
 	if _, err := w.out.Write(buf); err != nil {
 		return err
 	}
@@ -91,18 +196,18 @@ func (w *textWriter) WriteMsg(m *message) error {
 }
 
 func (w *termWriter) WriteMsg(m *message) error {
-	buf := make([]byte, 0, bufSize)
-	buf = append(buf, "\x1b["...) // ESC [
-	buf = append(buf, levelColor[m.level]...)
-	buf = append(buf, 'm', levelChar[m.level], ' ')
-	buf = appendTimestamp(buf, m.timestamp, m.flags)
-	buf = append(buf, ' ')
-	buf = append(buf, m.file...)
-	buf = append(buf, ':')
-	buf = strconv.AppendInt(buf, int64(m.line), 10)
-	buf = append(buf, ' ')
-	buf = m.appendMsg(buf)
+	f := m.format
+	if f == nil {
+		f = defaultFormat
+	}
+	b := getBuffer()
+	defer putBuffer(b)
+	buf := append(*b, "\x1b["...) // ESC [
+	buf = append(buf, f.colors[m.level]...)
+	buf = append(buf, 'm')
+	buf = f.appendLine(buf, m, true)
 	buf = append(buf, "\x1b[0m\n"...)
+	*b = buf
 	if _, err := w.out.Write(buf); err != nil {
 		return err
 	}
@@ -0,0 +1,221 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build linux && !android
+
+package slog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const journalSocket = "/run/systemd/journal/socket"
+
+type journalWriter struct {
+	tag  string
+	addr *net.UnixAddr
+	conn *net.UnixConn
+}
+
+func init() {
+	newJournalWriter = func(tag, path string) (output, error) {
+		if path == "" {
+			path = journalSocket
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+		if err != nil {
+			return nil, err
+		}
+		return &journalWriter{
+			tag:  tag,
+			addr: &net.UnixAddr{Name: path, Net: "unixgram"},
+			conn: conn,
+		}, nil
+	}
+}
+
+var journalPriority = [...]byte{
+	LevelSilence:     '1', /* LOG_ALERT */
+	LevelFatal:       '2', /* LOG_CRIT */
+	LevelError:       '3', /* LOG_ERR */
+	LevelWarning:     '4', /* LOG_WARNING */
+	LevelNotice:      '5', /* LOG_NOTICE */
+	LevelInfo:        '6', /* LOG_INFO */
+	LevelDebug:       '7', /* LOG_DEBUG */
+	LevelVerbose:     '7', /* LOG_DEBUG */
+	LevelVeryVerbose: '7', /* LOG_DEBUG */
+}
+
+// appendJournalField appends a field in the journal native protocol.
+// Values containing newlines are sent with an explicit length.
+func appendJournalField(b []byte, key string, value []byte) []byte {
+	b = append(b, key...)
+	if bytes.IndexByte(value, '\n') < 0 {
+		b = append(b, '=')
+		b = append(b, value...)
+		return append(b, '\n')
+	}
+	b = append(b, '\n')
+	b = binary.LittleEndian.AppendUint64(b, uint64(len(value)))
+	b = append(b, value...)
+	return append(b, '\n')
+}
+
+// journalReserved lists the prefixes of fields set by the writer or interpreted by journald,
+// which attributes must not override.
+var journalReserved = [...]string{"PRIORITY", "MESSAGE", "CODE_", "SYSLOG_"}
+
+// journalFieldName converts an attribute key to a valid journal field name,
+// or returns an empty string if it cannot be converted.
+// Names colliding with reserved fields are prefixed with "ATTR_".
+func journalFieldName(key string) string {
+	b := make([]byte, 0, len(key))
+	for i := 0; i < len(key) && len(b) < 64; i++ {
+		c := key[i]
+		switch {
+		case 'A' <= c && c <= 'Z':
+		case 'a' <= c && c <= 'z':
+			c -= 'a' - 'A'
+		case '0' <= c && c <= '9', c == '_':
+			if len(b) == 0 {
+				continue
+			}
+		default:
+			if len(b) == 0 {
+				continue
+			}
+			c = '_'
+		}
+		b = append(b, c)
+	}
+	name := string(b)
+	for _, reserved := range journalReserved {
+		if strings.HasPrefix(name, reserved) {
+			if len(name) > 64-len("ATTR_") {
+				name = name[:64-len("ATTR_")]
+			}
+			return "ATTR_" + name
+		}
+	}
+	return name
+}
+
+func (j *journalWriter) WriteMsg(m *message) error {
+	file, line := m.caller()
+	buf := make([]byte, 0, bufSize)
+	buf = append(buf, "PRIORITY="...)
+	buf = append(buf, journalPriority[m.level], '\n')
+	buf = appendJournalField(buf, "CODE_FILE", []byte(file))
+	buf = append(buf, "CODE_LINE="...)
+	buf = strconv.AppendInt(buf, int64(line), 10)
+	buf = append(buf, '\n')
+	if j.tag != "" {
+		buf = appendJournalField(buf, "SYSLOG_IDENTIFIER", []byte(j.tag))
+	}
+	msg := append([]byte(nil), m.text...)
+	msg = appendAttrs(msg, m.attrs)
+	if m.writeExtra != nil {
+		extra := bytes.NewBuffer(append(msg, '\n'))
+		if err := m.writeExtra(extra); err != nil {
+			return err
+		}
+		msg = bytes.TrimSuffix(extra.Bytes(), []byte{'\n'})
+	}
+	buf = appendJournalField(buf, "MESSAGE", msg)
+	var value [64]byte
+	for _, a := range m.attrs {
+		if key := journalFieldName(a.Key); key != "" {
+			buf = appendJournalField(buf, key, a.AppendValue(value[:0]))
+		}
+	}
+	return j.send(buf)
+}
+
+func (j *journalWriter) send(b []byte) error {
+	_, _, err := j.conn.WriteMsgUnix(b, nil, j.addr)
+	if err == nil || !(errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS)) {
+		return err
+	}
+	/* too large for a datagram, pass a file descriptor instead */
+	f, err := sealedFile(b)
+	if err != nil {
+		if f, err = shmFile(b); err != nil {
+			return err
+		}
+	}
+	defer f.Close()
+	_, _, err = j.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), j.addr)
+	return err
+}
+
+// sysMemfdCreate is the number of memfd_create, which the syscall package lacks on some architectures.
+var sysMemfdCreate = map[string]uintptr{
+	"386": 356, "amd64": 319, "arm": 385, "arm64": 279, "loong64": 279,
+	"mips": 4354, "mipsle": 4354, "mips64": 5314, "mips64le": 5314,
+	"ppc64": 360, "ppc64le": 360, "riscv64": 279, "s390x": 350,
+}[runtime.GOARCH]
+
+const (
+	mfdCloexec      = 0x1
+	mfdAllowSealing = 0x2
+	fAddSeals       = 0x409
+	fSealAll        = 0x1 | 0x2 | 0x4 | 0x8 /* F_SEAL_SEAL | F_SEAL_SHRINK | F_SEAL_GROW | F_SEAL_WRITE */
+)
+
+// sealedFile returns a sealed memfd holding b, as preferred by journald.
+func sealedFile(b []byte) (*os.File, error) {
+	if sysMemfdCreate == 0 {
+		return nil, syscall.ENOSYS
+	}
+	name, err := syscall.BytePtrFromString("journal-message")
+	if err != nil {
+		return nil, err
+	}
+	fd, _, errno := syscall.Syscall(sysMemfdCreate, uintptr(unsafe.Pointer(name)), mfdCloexec|mfdAllowSealing, 0)
+	if errno != 0 {
+		return nil, errno
+	}
+	f := os.NewFile(fd, "journal-message")
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_FCNTL, fd, fAddSeals, fSealAll); errno != 0 {
+		_ = f.Close()
+		return nil, errno
+	}
+	return f, nil
+}
+
+// shmFile returns an unlinked file in /dev/shm holding b, for kernels without memfd.
+func shmFile(b []byte) (*os.File, error) {
+	f, err := os.CreateTemp("/dev/shm", "journal.*")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(f.Name()); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (j *journalWriter) Close() error {
+	return j.conn.Close()
+}
@@ -0,0 +1,194 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build linux && !android
+
+package slog_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func listenJournal(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, path
+}
+
+func parseJournalFields(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	for len(b) > 0 {
+		i := bytes.IndexAny(b, "=\n")
+		if i < 0 {
+			t.Fatalf("malformed entry: %q", b)
+		}
+		key := string(b[:i])
+		if b[i] == '=' {
+			j := bytes.IndexByte(b, '\n')
+			fields[key] = string(b[i+1 : j])
+			b = b[j+1:]
+			continue
+		}
+		n := binary.LittleEndian.Uint64(b[i+1 : i+9])
+		fields[key] = string(b[i+9 : i+9+int(n)])
+		b = b[i+9+int(n)+1:]
+	}
+	return fields
+}
+
+func TestJournalOutput(t *testing.T) {
+	conn, path := listenJournal(t)
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputJournal, "slogtest", path)
+	logger.SetLevel(slog.LevelDebug)
+	defer logger.SetOutput(slog.OutputDiscard)
+
+	logger.Warningw("hello", slog.String("peer", "1.2.3.4"), slog.Int("conn-id", 7), slog.String("_trusted", "x"))
+	buf := make([]byte, 65536)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := parseJournalFields(t, buf[:n])
+	expected := map[string]string{
+		"PRIORITY":          "4",
+		"SYSLOG_IDENTIFIER": "slogtest",
+		"MESSAGE":           "hello peer=1.2.3.4 conn-id=7 _trusted=x",
+		"PEER":              "1.2.3.4",
+		"CONN_ID":           "7",
+		"TRUSTED":           "x",
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("%s: expected %q, got %q", k, v, fields[k])
+		}
+	}
+	if !strings.HasSuffix(fields["CODE_FILE"], "output_journal_linux_test.go") || fields["CODE_LINE"] == "" {
+		t.Errorf("unexpected source location: %s:%s", fields["CODE_FILE"], fields["CODE_LINE"])
+	}
+
+	logger.Println(0, slog.LevelError, func(w io.Writer) error {
+		_, err := io.WriteString(w, "line1\nline2\n")
+		return err
+	}, "multiline")
+	n, err = conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields = parseJournalFields(t, buf[:n])
+	if fields["MESSAGE"] != "multiline\nline1\nline2" {
+		t.Errorf("unexpected message: %q", fields["MESSAGE"])
+	}
+}
+
+func TestJournalOutputReserved(t *testing.T) {
+	conn, path := listenJournal(t)
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputJournal, "slogtest", path)
+	logger.SetLevel(slog.LevelDebug)
+	defer logger.SetOutput(slog.OutputDiscard)
+
+	logger.Infow("real",
+		slog.String("priority", "0"),
+		slog.String("message", "forged"),
+		slog.String("code_file", "forged.go"),
+		slog.String("syslog_identifier", "forged"),
+	)
+	buf := make([]byte, 65536)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	for _, line := range bytes.Split(buf[:n], []byte{'\n'}) {
+		if key, _, ok := bytes.Cut(line, []byte{'='}); ok {
+			counts[string(key)]++
+		}
+	}
+	for _, key := range []string{"PRIORITY", "MESSAGE", "CODE_FILE", "SYSLOG_IDENTIFIER"} {
+		if counts[key] != 1 {
+			t.Errorf("%s: expected once, got %d", key, counts[key])
+		}
+	}
+	fields := parseJournalFields(t, buf[:n])
+	expected := map[string]string{
+		"PRIORITY":               "6",
+		"SYSLOG_IDENTIFIER":      "slogtest",
+		"ATTR_PRIORITY":          "0",
+		"ATTR_MESSAGE":           "forged",
+		"ATTR_CODE_FILE":         "forged.go",
+		"ATTR_SYSLOG_IDENTIFIER": "forged",
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("%s: expected %q, got %q", k, v, fields[k])
+		}
+	}
+	if !strings.HasPrefix(fields["MESSAGE"], "real ") {
+		t.Errorf("unexpected message: %q", fields["MESSAGE"])
+	}
+}
+
+func TestJournalOutputLarge(t *testing.T) {
+	conn, path := listenJournal(t)
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputJournal, "slogtest", path)
+	logger.SetLevel(slog.LevelDebug)
+	defer logger.SetOutput(slog.OutputDiscard)
+
+	large := strings.Repeat("x", 1<<20)
+	done := make(chan error, 1)
+	go func() {
+		done <- logger.Log(0, slog.LevelInfo, nil, large)
+	}()
+	buf := make([]byte, 65536)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	_, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("expected a file descriptor, got %v (%v)", msgs, err)
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("expected a file descriptor, got %v (%v)", fds, err)
+	}
+	f := os.NewFile(uintptr(fds[0]), "journal")
+	defer f.Close()
+	const fGetSeals, fSealWrite = 0x40a, 0x8
+	if seals, _, errno := syscall.Syscall(syscall.SYS_FCNTL, f.Fd(), fGetSeals, 0); errno != 0 || seals&fSealWrite == 0 {
+		t.Errorf("expected a sealed memfd, got seals %#x (%v)", seals, errno)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := parseJournalFields(t, b)
+	if fields["MESSAGE"] != large {
+		t.Errorf("unexpected message length: %d", len(fields["MESSAGE"]))
+	}
+}
@@ -0,0 +1,140 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+type jsonWriter struct {
+	out WriteFlusher
+}
+
+func newJSONWriter(out io.Writer) output {
+	return &jsonWriter{out: NewWriteFlusher(out)}
+}
+
+const hexDigits = "0123456789abcdef"
+
+// jsonReserved are the fields written for every message. Attributes with these keys are
+// written with the jsonAttrPrefix so that the fields are never duplicated.
+var jsonReserved = [...]string{"time", "level", "file", "line", "msg", "extra"}
+
+const jsonAttrPrefix = "attr."
+
+func appendJSONKey(b []byte, key string) []byte {
+	for _, k := range jsonReserved {
+		if key == k {
+			return appendJSONString(b, []byte(jsonAttrPrefix+key))
+		}
+	}
+	return appendJSONString(b, []byte(key))
+}
+
+func appendJSONString(b []byte, s []byte) []byte {
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				b = append(b, '\\', c)
+			case c == '\n':
+				b = append(b, '\\', 'n')
+			case c == '\r':
+				b = append(b, '\\', 'r')
+			case c == '\t':
+				b = append(b, '\\', 't')
+			case c < 0x20 || c == 0x7f:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			default:
+				b = append(b, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, `\ufffd`...)
+		} else {
+			b = append(b, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(b, '"')
+}
+
+func appendJSONValue(b []byte, a Attr) []byte {
+	switch a.kind {
+	case KindInt64, KindUint64, KindBool:
+		return a.AppendValue(b)
+	case KindFloat64:
+		if f := math.Float64frombits(a.num); math.IsNaN(f) || math.IsInf(f, 0) {
+			return appendJSONString(b, a.AppendValue(nil))
+		}
+		return a.AppendValue(b)
+	case KindString:
+		return appendJSONString(b, []byte(a.str))
+	case KindAny:
+		if _, ok := a.any.(error); ok {
+			break
+		}
+		if v, err := json.Marshal(a.any); err == nil {
+			return append(b, v...)
+		}
+	}
+	var buf [64]byte
+	return appendJSONString(b, a.AppendValue(buf[:0]))
+}
+
+func appendJSONExtra(b []byte, extra []byte) []byte {
+	b = append(b, `,"extra":[`...)
+	for i, line := range bytes.Split(bytes.TrimSuffix(extra, []byte{'\n'}), []byte{'\n'}) {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, line)
+	}
+	return append(b, ']')
+}
+
+func (w *jsonWriter) WriteMsg(m *message) error {
+	b := getBuffer()
+	defer putBuffer(b)
+	file, line := m.caller()
+	buf := append(*b, `{"time":"`...)
+	buf = appendTimestamp(buf, m.timestamp, m.flags)
+	buf = append(buf, `","level":"`...)
+	buf = append(buf, levelName[m.level]...)
+	buf = append(buf, `","file":`...)
+	buf = appendJSONString(buf, []byte(file))
+	buf = append(buf, `,"line":`...)
+	buf = strconv.AppendInt(buf, int64(line), 10)
+	buf = append(buf, `,"msg":`...)
+	buf = appendJSONString(buf, m.text)
+	for _, a := range m.attrs {
+		buf = append(buf, ',')
+		buf = appendJSONKey(buf, a.Key)
+		buf = append(buf, ':')
+		buf = appendJSONValue(buf, a)
+	}
+	if m.writeExtra != nil {
+		var extra bytes.Buffer
+		if err := m.writeExtra(&extra); err != nil {
+			return err
+		}
+		buf = appendJSONExtra(buf, extra.Bytes())
+	}
+	buf = append(buf, '}', '\n')
+	*b = buf
+	if _, err := w.out.Write(buf); err != nil {
+		return err
+	}
+	return w.out.Flush()
+}
@@ -0,0 +1,131 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func decodeJSONLines(t *testing.T, b []byte) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n") {
+		var v map[string]any
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		records = append(records, v)
+	}
+	return records
+}
+
+func TestJSONOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputJSON, &buf)
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetFlags(slog.FlagUTC)
+
+	logger.Warningw("quote \" and\nnewline\x01",
+		slog.String("peer", "127.0.0.1:80"),
+		slog.Int("conn", 3),
+		slog.Bool("ok", false),
+		slog.Float64("nan", math.NaN()),
+		slog.Duration("rtt", time.Second),
+		slog.Err(errors.New("broken pipe")),
+		slog.Any("list", []int{1, 2}))
+
+	records := decodeJSONLines(t, buf.Bytes())
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	expected := map[string]any{
+		"level": "warning",
+		"msg":   "quote \" and\nnewline\x01",
+		"peer":  "127.0.0.1:80",
+		"conn":  float64(3),
+		"ok":    false,
+		"nan":   "NaN",
+		"rtt":   "1s",
+		"error": "broken pipe",
+	}
+	for k, v := range expected {
+		if r[k] != v {
+			t.Errorf("%s: expected %#v, got %#v", k, v, r[k])
+		}
+	}
+	if list, ok := r["list"].([]any); !ok || len(list) != 2 {
+		t.Errorf("list: expected JSON array, got %#v", r["list"])
+	}
+	if ts, ok := r["time"].(string); !ok || !strings.HasSuffix(ts, "Z") {
+		t.Errorf("time: expected UTC timestamp, got %#v", r["time"])
+	}
+	if file, ok := r["file"].(string); !ok || !strings.HasSuffix(file, "output_json_test.go") {
+		t.Errorf("file: expected caller file, got %#v", r["file"])
+	}
+	if line, ok := r["line"].(float64); !ok || line <= 0 {
+		t.Errorf("line: expected positive line, got %#v", r["line"])
+	}
+}
+
+func TestJSONOutputExtra(t *testing.T) {
+	var buf bytes.Buffer
+	slog.Default().SetOutput(slog.OutputJSON, &buf)
+	slog.Default().SetLevel(slog.LevelDebug)
+
+	slog.Text(slog.LevelDebug, "line1\nline2", "text")
+	slog.Binary(slog.LevelDebug, []byte("ABC"), "binary")
+	slog.Debug("plain")
+
+	records := decodeJSONLines(t, buf.Bytes())
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	text, ok := records[0]["extra"].([]any)
+	if !ok || len(text) != 2 || !strings.Contains(text[1].(string), "line2") {
+		t.Errorf("expected text payload lines, got %#v", records[0]["extra"])
+	}
+	bin, ok := records[1]["extra"].([]any)
+	if !ok || len(bin) != 1 || !strings.Contains(bin[0].(string), "41 42 43") {
+		t.Errorf("expected binary payload lines, got %#v", records[1]["extra"])
+	}
+	if _, ok := records[2]["extra"]; ok {
+		t.Errorf("expected no payload, got %#v", records[2]["extra"])
+	}
+}
+
+func TestJSONOutputReserved(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputJSON, &buf)
+	logger.SetLevel(slog.LevelInfo)
+
+	logger.Infow("real", slog.String("msg", "fake"), slog.String("level", "fatal"), slog.Int("line", 0))
+
+	if n := strings.Count(buf.String(), `"msg":`); n != 1 {
+		t.Errorf("expected a single msg field, got %d: %s", n, buf.String())
+	}
+	r := decodeJSONLines(t, buf.Bytes())[0]
+	expected := map[string]any{
+		"msg":        "real",
+		"level":      "info",
+		"attr.msg":   "fake",
+		"attr.level": "fatal",
+		"attr.line":  float64(0),
+	}
+	for k, v := range expected {
+		if r[k] != v {
+			t.Errorf("%s: expected %#v, got %#v", k, v, r[k])
+		}
+	}
+}
@@ -18,19 +18,35 @@ type logdWriter struct {
 	out net.Conn
 }
 
+const logdSocket = "/dev/socket/logdw"
+
 func init() {
-	newSyslogWriter = func(tag string) output {
-		conn, err := net.Dial("unixgram", "/dev/socket/logdw")
+	newSyslogWriter = func(tag string) (output, error) {
+		conn, err := net.Dial("unixgram", logdSocket)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		return &logdWriter{
 			tag: []byte(tag),
 			out: conn,
-		}
+		}, nil
 	}
 }
 
+func (l *logdWriter) reconnect() error {
+	conn, err := net.Dial("unixgram", logdSocket)
+	if err != nil {
+		return err
+	}
+	_ = l.out.Close()
+	l.out = conn
+	return nil
+}
+
+func (l *logdWriter) Close() error {
+	return l.out.Close()
+}
+
 var levelMap = [...]byte{
 	LevelSilence:     8, /* ANDROID_LOG_SILENT */
 	LevelFatal:       7, /* ANDROID_LOG_FATAL */
@@ -57,11 +73,13 @@ func (l *logdWriter) WriteMsg(m *message) error {
 	buf = append(buf, 0)
 
 	buf = append(buf, levelChar[m.level], ' ')
-	buf = append(buf, m.file...)
+	file, line := m.caller()
+	buf = append(buf, file...)
 	buf = append(buf, ':')
-	buf = strconv.AppendInt(buf, int64(m.line), 10)
+	buf = strconv.AppendInt(buf, int64(line), 10)
 	buf = append(buf, ' ')
-	buf = m.appendMsg(buf)
+	buf = append(buf, m.text...)
+	buf = appendAttrs(buf, m.attrs)
 	buf = append(buf, 0)
 	_, err := l.out.Write(buf)
 	return err
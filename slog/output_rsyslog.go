@@ -0,0 +1,436 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RemoteSyslogConfig specifies a remote syslog output.
+type RemoteSyslogConfig struct {
+	// Network is "udp", "tcp" or "tls".
+	Network string
+	// Addr is the address of the syslog server, e.g. "logs.example.com:514".
+	Addr string
+	// TLS is the TLS configuration for the "tls" network, nil means the defaults.
+	TLS *tls.Config
+	// RFC3164 selects the BSD syslog format instead of RFC 5424.
+	RFC3164 bool
+	// Facility is the syslog facility from 0 to 23, e.g. 1 for user-level or 16 for local0.
+	// 0 means the default of 1.
+	Facility int
+	// Hostname defaults to os.Hostname.
+	Hostname string
+	// AppName defaults to the program name.
+	AppName string
+	// StructuredDataID is the SD-ID of RFC 5424 structured data holding the attributes,
+	// "attrs@32473" by default.
+	StructuredDataID string
+	// Backlog is the number of messages kept while disconnected, 1000 by default.
+	// New messages are dropped when the backlog is full.
+	Backlog int
+}
+
+const (
+	rsyslogDialTimeout  = 10 * time.Second
+	rsyslogWriteTimeout = 10 * time.Second
+	rsyslogCloseTimeout = 2 * time.Second
+	rsyslogFlushTimeout = 2 * time.Second
+	rsyslogMinBackoff   = 100 * time.Millisecond
+	rsyslogMaxBackoff   = 30 * time.Second
+	rsyslogMaxDatagram  = 65000
+)
+
+var (
+	errBacklogFull  = errors.New("slog: remote syslog backlog is full")
+	errFlushTimeout = errors.New("slog: remote syslog backlog was not sent in time")
+)
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// ParseFacility parses a syslog facility name like "daemon" or "local0", or a number from 0 to 23.
+func ParseFacility(s string) (int, error) {
+	if f, ok := syslogFacilities[s]; ok {
+		return f, nil
+	}
+	if f, err := strconv.Atoi(s); err == nil && f >= 0 && f <= 23 {
+		return f, nil
+	}
+	return 0, fmt.Errorf("slog: invalid syslog facility %q", s)
+}
+
+var syslogSeverity = [...]int{
+	LevelSilence:     1, /* LOG_ALERT */
+	LevelFatal:       2, /* LOG_CRIT */
+	LevelError:       3, /* LOG_ERR */
+	LevelWarning:     4, /* LOG_WARNING */
+	LevelNotice:      5, /* LOG_NOTICE */
+	LevelInfo:        6, /* LOG_INFO */
+	LevelDebug:       7, /* LOG_DEBUG */
+	LevelVerbose:     7, /* LOG_DEBUG */
+	LevelVeryVerbose: 7, /* LOG_DEBUG */
+}
+
+// rsyslogWriter formats messages and queues them for a sender goroutine,
+// which connects and reconnects to the server with exponential backoff.
+type rsyslogWriter struct {
+	cfg RemoteSyslogConfig
+	pid string
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	drained *sync.Cond // signaled when the backlog is empty or the sender stops
+	backlog [][]byte
+	closed  bool
+	aborted bool     // Close timed out
+	stopped bool     // the sender has stopped
+	conn    net.Conn // written by the sender with mu held
+
+	ctx    context.Context // canceled when Close times out
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func newRemoteSyslogWriter(cfg *RemoteSyslogConfig) (output, error) {
+	w := &rsyslogWriter{
+		cfg:  *cfg,
+		pid:  strconv.Itoa(os.Getpid()),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	switch w.cfg.Network {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("slog: invalid remote syslog network %q", w.cfg.Network)
+	}
+	if _, _, err := net.SplitHostPort(w.cfg.Addr); err != nil {
+		return nil, fmt.Errorf("slog: invalid remote syslog address %q: %w", w.cfg.Addr, err)
+	}
+	if w.cfg.Facility == 0 {
+		w.cfg.Facility = 1
+	} else if w.cfg.Facility < 0 || w.cfg.Facility > 23 {
+		return nil, fmt.Errorf("slog: invalid syslog facility %d", w.cfg.Facility)
+	}
+	if w.cfg.Hostname == "" {
+		w.cfg.Hostname, _ = os.Hostname()
+	}
+	if w.cfg.AppName == "" {
+		w.cfg.AppName = filepath.Base(os.Args[0])
+	}
+	if w.cfg.StructuredDataID == "" {
+		w.cfg.StructuredDataID = "attrs@32473"
+	}
+	if w.cfg.Backlog <= 0 {
+		w.cfg.Backlog = 1000
+	}
+	w.cond = sync.NewCond(&w.mu)
+	w.drained = sync.NewCond(&w.mu)
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	go w.run()
+	return w, nil
+}
+
+// appendHeaderField appends a header field of printable ASCII, or "-" if empty.
+func appendHeaderField(b []byte, s string, maxLen int) []byte {
+	if s == "" {
+		return append(b, '-')
+	}
+	for i := 0; i < len(s) && i < maxLen; i++ {
+		c := s[i]
+		if c <= ' ' || c > '~' {
+			c = '_'
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+// appendSDName appends an SD-NAME, which is printable ASCII except '=', ' ', ']' and '"'.
+func appendSDName(b []byte, s string) []byte {
+	for i := 0; i < len(s) && i < 32; i++ {
+		c := s[i]
+		if c <= ' ' || c > '~' || c == '=' || c == ']' || c == '"' {
+			c = '_'
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func appendSDValue(b []byte, a Attr) []byte {
+	var buf [64]byte
+	for _, c := range a.AppendValue(buf[:0]) {
+		if c == '"' || c == '\\' || c == ']' {
+			b = append(b, '\\')
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+func hasKeyedAttrs(attrs []Attr) bool {
+	for _, a := range attrs {
+		if a.Key != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *rsyslogWriter) format(m *message) []byte {
+	file, line := m.caller()
+	pri := w.cfg.Facility*8 + syslogSeverity[m.level]
+	b := append(make([]byte, 0, bufSize), '<')
+	b = strconv.AppendInt(b, int64(pri), 10)
+	b = append(b, '>')
+	if w.cfg.RFC3164 {
+		b = m.timestamp.AppendFormat(b, time.Stamp)
+		b = append(b, ' ')
+		b = appendHeaderField(b, w.cfg.Hostname, 255)
+		b = append(b, ' ')
+		b = appendHeaderField(b, w.cfg.AppName, 32)
+		b = append(b, '[')
+		b = append(b, w.pid...)
+		b = append(b, "]: "...)
+	} else {
+		b = append(b, "1 "...)
+		b = m.timestamp.AppendFormat(b, "2006-01-02T15:04:05.000000Z07:00")
+		b = append(b, ' ')
+		b = appendHeaderField(b, w.cfg.Hostname, 255)
+		b = append(b, ' ')
+		b = appendHeaderField(b, w.cfg.AppName, 48)
+		b = append(b, ' ')
+		b = append(b, w.pid...)
+		b = append(b, " - "...)
+		if !hasKeyedAttrs(m.attrs) {
+			b = append(b, '-')
+		} else {
+			b = append(b, '[')
+			b = appendSDName(b, w.cfg.StructuredDataID)
+			for _, a := range m.attrs {
+				if a.Key == "" {
+					/* an SD-NAME must not be empty */
+					continue
+				}
+				b = append(b, ' ')
+				b = appendSDName(b, a.Key)
+				b = append(b, '=', '"')
+				b = appendSDValue(b, a)
+				b = append(b, '"')
+			}
+			b = append(b, ']')
+		}
+		b = append(b, ' ')
+	}
+	b = append(b, file...)
+	b = append(b, ':')
+	b = strconv.AppendInt(b, int64(line), 10)
+	b = append(b, ' ')
+	b = append(b, m.text...)
+	if w.cfg.RFC3164 {
+		b = appendAttrs(b, m.attrs)
+	}
+	if m.writeExtra != nil {
+		extra := &bufWriter{b: append(b, '\n')}
+		if err := m.writeExtra(extra); err == nil {
+			b = extra.b
+			if b[len(b)-1] == '\n' {
+				b = b[:len(b)-1]
+			}
+		}
+	}
+	if w.cfg.Network == "udp" && len(b) > rsyslogMaxDatagram {
+		b = b[:rsyslogMaxDatagram]
+	}
+	return b
+}
+
+// bufWriter is an io.Writer appending to a byte slice.
+type bufWriter struct {
+	b []byte
+}
+
+func (w *bufWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
+
+func (w *rsyslogWriter) WriteMsg(m *message) error {
+	frame := w.format(m)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return net.ErrClosed
+	}
+	if len(w.backlog) >= w.cfg.Backlog {
+		return errBacklogFull
+	}
+	w.backlog = append(w.backlog, frame)
+	w.cond.Signal()
+	return nil
+}
+
+func (w *rsyslogWriter) dial() (net.Conn, error) {
+	d := &net.Dialer{Timeout: rsyslogDialTimeout}
+	if w.cfg.Network == "tls" {
+		td := &tls.Dialer{NetDialer: d, Config: w.cfg.TLS}
+		return td.DialContext(w.ctx, "tcp", w.cfg.Addr)
+	}
+	return d.DialContext(w.ctx, w.cfg.Network, w.cfg.Addr)
+}
+
+func (w *rsyslogWriter) setConn(conn net.Conn) {
+	w.mu.Lock()
+	w.conn = conn
+	w.mu.Unlock()
+}
+
+func (w *rsyslogWriter) send(frame []byte) error {
+	if w.conn == nil {
+		conn, err := w.dial()
+		if err != nil {
+			return err
+		}
+		w.setConn(conn)
+	}
+	/* the deadline is set with mu held so that Close can override it */
+	w.mu.Lock()
+	if w.aborted {
+		w.mu.Unlock()
+		return net.ErrClosed
+	}
+	err := w.conn.SetWriteDeadline(time.Now().Add(rsyslogWriteTimeout))
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if w.cfg.Network == "udp" {
+		_, err := w.conn.Write(frame)
+		return err
+	}
+	/* RFC 6587 octet counting */
+	var hdr [24]byte
+	bufs := net.Buffers{append(strconv.AppendInt(hdr[:0], int64(len(frame)), 10), ' '), frame}
+	_, err = bufs.WriteTo(w.conn)
+	return err
+}
+
+func (w *rsyslogWriter) run() {
+	defer close(w.done)
+	backoff := rsyslogMinBackoff
+	for {
+		w.mu.Lock()
+		for len(w.backlog) == 0 && !w.closed {
+			w.cond.Wait()
+		}
+		if len(w.backlog) == 0 {
+			w.mu.Unlock()
+			break
+		}
+		frame := w.backlog[0]
+		closed := w.closed
+		w.mu.Unlock()
+
+		if err := w.send(frame); err != nil {
+			if w.conn != nil {
+				_ = w.conn.Close()
+				w.setConn(nil)
+			}
+			if closed {
+				break
+			}
+			select {
+			case <-time.After(backoff):
+			case <-w.stop:
+			}
+			if backoff *= 2; backoff > rsyslogMaxBackoff {
+				backoff = rsyslogMaxBackoff
+			}
+			continue
+		}
+		backoff = rsyslogMinBackoff
+		w.mu.Lock()
+		w.backlog[0] = nil
+		w.backlog = w.backlog[1:]
+		if len(w.backlog) == 0 {
+			w.drained.Broadcast()
+		}
+		w.mu.Unlock()
+	}
+	if w.conn != nil {
+		_ = w.conn.Close()
+	}
+	w.mu.Lock()
+	w.stopped = true
+	w.drained.Broadcast()
+	w.mu.Unlock()
+}
+
+// flush waits up to rsyslogFlushTimeout until the backlog is sent.
+func (w *rsyslogWriter) flush() error {
+	timedOut := false
+	timer := time.AfterFunc(rsyslogFlushTimeout, func() {
+		w.mu.Lock()
+		timedOut = true
+		w.drained.Broadcast()
+		w.mu.Unlock()
+	})
+	defer timer.Stop()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for len(w.backlog) > 0 && !w.stopped && !timedOut {
+		w.drained.Wait()
+	}
+	if len(w.backlog) > 0 {
+		return errFlushTimeout
+	}
+	return nil
+}
+
+// Close sends the backlog if connected, then closes the connection.
+// Messages not sent within rsyslogCloseTimeout are dropped.
+func (w *rsyslogWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.cond.Signal()
+	w.mu.Unlock()
+	close(w.stop)
+	defer w.cancel()
+	timer := time.NewTimer(rsyslogCloseTimeout)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+		return nil
+	case <-timer.C:
+	}
+	/* interrupt the pending dial or write */
+	w.cancel()
+	w.mu.Lock()
+	w.aborted = true
+	if w.conn != nil {
+		_ = w.conn.SetDeadline(time.Now())
+	}
+	w.mu.Unlock()
+	<-w.done
+	return nil
+}
@@ -0,0 +1,346 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+// readOctetCounted reads one RFC 6587 octet-counted frame.
+func readOctetCounted(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	n, err := r.ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	size, err := strconv.Atoi(strings.TrimSuffix(n, " "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func acceptReader(t *testing.T, l net.Listener) *bufio.Reader {
+	t.Helper()
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return bufio.NewReader(conn)
+}
+
+func TestRemoteSyslogUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutputSpec("syslog+udp:" + pc.LocalAddr().String() + "?facility=local0&hostname=host&app=app"); err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetOutput(slog.OutputDiscard)
+	logger.Infow("hello", slog.String("k", `v "q"`), slog.Int("n", 1))
+
+	_ = pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 65536)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	re := regexp.MustCompile(`^<134>1 \d{4}-\d\d-\d\dT\S+ host app \d+ - \[attrs@32473 k="v \\"q\\"" n="1"\] \S+output_rsyslog_test\.go:\d+ hello$`)
+	if !re.Match(buf[:n]) {
+		t.Errorf("unexpected message: %q", buf[:n])
+	}
+}
+
+func TestRemoteSyslogTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputRemoteSyslog, &slog.RemoteSyslogConfig{
+		Network:  "tcp",
+		Addr:     l.Addr().String(),
+		RFC3164:  true,
+		Hostname: "host",
+		AppName:  "app",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetOutput(slog.OutputDiscard)
+	logger.Infow("hello", slog.String("k", "v"))
+	logger.Error("multi\nline")
+
+	r := acceptReader(t, l)
+	re := regexp.MustCompile(`^<14>\w{3} [ \d]\d \d\d:\d\d:\d\d host app\[\d+\]: \S+:\d+ hello k=v$`)
+	if msg := readOctetCounted(t, r); !re.MatchString(msg) {
+		t.Errorf("unexpected message: %q", msg)
+	}
+	if msg := readOctetCounted(t, r); !strings.HasPrefix(msg, "<11>") || !strings.HasSuffix(msg, "multi\nline") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestRemoteSyslogBacklog(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputRemoteSyslog, &slog.RemoteSyslogConfig{
+		Network: "tcp",
+		Addr:    addr,
+		Backlog: 2,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetOutput(slog.OutputDiscard)
+	for i := 0; i < 3; i++ {
+		err := logger.Log(0, slog.LevelInfo, nil, "message "+strconv.Itoa(i))
+		if (err != nil) != (i == 2) {
+			t.Errorf("message %d: unexpected error %v", i, err)
+		}
+	}
+
+	if l, err = net.Listen("tcp", addr); err != nil {
+		t.Skip(err)
+	}
+	defer l.Close()
+	r := acceptReader(t, l)
+	for i := 0; i < 2; i++ {
+		if msg := readOctetCounted(t, r); !strings.HasSuffix(msg, "message "+strconv.Itoa(i)) {
+			t.Errorf("unexpected message: %q", msg)
+		}
+	}
+}
+
+func TestRemoteSyslogTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputRemoteSyslog, &slog.RemoteSyslogConfig{
+		Network: "tls",
+		Addr:    l.Addr().String(),
+		TLS:     &tls.Config{RootCAs: roots},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetOutput(slog.OutputDiscard)
+	logger.Warning("secure")
+
+	r := acceptReader(t, l)
+	if msg := readOctetCounted(t, r); !strings.HasPrefix(msg, "<12>1 ") || !strings.HasSuffix(msg, "secure") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestRemoteSyslogConfig(t *testing.T) {
+	logger := slog.NewLogger()
+	for _, cfg := range []*slog.RemoteSyslogConfig{
+		{Network: "sctp", Addr: "127.0.0.1:514"},
+		{Network: "udp", Addr: "127.0.0.1"},
+		{Network: "udp", Addr: "127.0.0.1:514", Facility: 24},
+	} {
+		if err := logger.SetOutput(slog.OutputRemoteSyslog, cfg); err == nil {
+			t.Errorf("expected error for %+v", cfg)
+		}
+	}
+	if err := logger.SetOutputSpec("syslog+udp:127.0.0.1:514?facility=nowhere"); err == nil {
+		t.Error("expected error for an invalid facility")
+	}
+}
+
+func TestRemoteSyslogEmptyKey(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutputSpec("syslog+udp:" + pc.LocalAddr().String() + "?hostname=host&app=app"); err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetOutput(slog.OutputDiscard)
+	logger.Infow("keyed", slog.String("", "x"), slog.String("k", "v"))
+	logger.Infow("unkeyed", slog.String("", "x"))
+
+	_ = pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 65536)
+	for _, re := range []*regexp.Regexp{
+		regexp.MustCompile(` - \[attrs@32473 k="v"\] \S+ keyed$`),
+		regexp.MustCompile(` - - \S+ unkeyed$`),
+	} {
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !re.Match(buf[:n]) {
+			t.Errorf("unexpected message: %q", buf[:n])
+		}
+	}
+}
+
+func TestRemoteSyslogCloseTimeout(t *testing.T) {
+	/* the server never completes the TLS handshake */
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputRemoteSyslog, &slog.RemoteSyslogConfig{
+		Network: "tls",
+		Addr:    l.Addr().String(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("pending")
+	start := time.Now()
+	if err := logger.SetOutput(slog.OutputDiscard); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("Close took %v", d)
+	}
+}
+
+func TestSetOutputCloseUnlocked(t *testing.T) {
+	/* the server never completes the TLS handshake, so Close blocks */
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputRemoteSyslog, &slog.RemoteSyslogConfig{
+		Network: "tls",
+		Addr:    l.Addr().String(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("pending")
+	c := slog.NewCapture()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = logger.SetOutput(slog.OutputCapture, c)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	logger.Info("not blocked")
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("logging was blocked for %v", d)
+	}
+	if _, ok := c.Find(slog.LevelInfo, "not blocked"); !ok {
+		t.Error("the message was not written to the new output")
+	}
+	<-done
+}
+
+func TestRemoteSyslogFlush(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetOutput(slog.OutputRemoteSyslog, &slog.RemoteSyslogConfig{
+		Network: "tcp",
+		Addr:    l.Addr().String(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer logger.SetOutput(slog.OutputDiscard)
+	logger.Error("flushed")
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	r := acceptReader(t, l)
+	if msg := readOctetCounted(t, r); !strings.HasSuffix(msg, "flushed") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+
+	/* the backlog can not be sent to a closed port */
+	addr := l.Addr().String()
+	l.Close()
+	if err := logger.SetOutput(slog.OutputRemoteSyslog, &slog.RemoteSyslogConfig{
+		Network: "tcp",
+		Addr:    addr,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	logger.Error("lost")
+	if err := logger.Close(); err == nil {
+		t.Error("expected an error for the backlog left")
+	}
+}
@@ -12,19 +12,34 @@ import (
 )
 
 type syslogWriter struct {
+	tag string
 	out *syslog.Writer
 }
 
 func init() {
-	newSyslogWriter = func(tag string) output {
+	newSyslogWriter = func(tag string) (output, error) {
 		w, err := syslog.New(syslog.LOG_USER|syslog.LOG_NOTICE, tag)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-		return &syslogWriter{w}
+		return &syslogWriter{tag, w}, nil
 	}
 }
 
+func (s *syslogWriter) reconnect() error {
+	w, err := syslog.New(syslog.LOG_USER|syslog.LOG_NOTICE, s.tag)
+	if err != nil {
+		return err
+	}
+	_ = s.out.Close()
+	s.out = w
+	return nil
+}
+
+func (s *syslogWriter) Close() error {
+	return s.out.Close()
+}
+
 var priorityMap = [...]func(*syslog.Writer, string) error{
 	(*syslog.Writer).Alert,
 	(*syslog.Writer).Crit,
@@ -40,10 +55,12 @@ var priorityMap = [...]func(*syslog.Writer, string) error{
 func (s *syslogWriter) WriteMsg(m *message) error {
 	buf := make([]byte, 0, bufSize)
 	buf = append(buf, levelChar[m.level], ' ')
-	buf = append(buf, m.file...)
+	file, line := m.caller()
+	buf = append(buf, file...)
 	buf = append(buf, ':')
-	buf = strconv.AppendInt(buf, int64(m.line), 10)
+	buf = strconv.AppendInt(buf, int64(line), 10)
 	buf = append(buf, ' ')
-	buf = m.appendMsg(buf)
+	buf = append(buf, m.text...)
+	buf = appendAttrs(buf, m.attrs)
 	return priorityMap[m.level](s.out, string(buf))
 }
@@ -0,0 +1,152 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+type rateState struct {
+	mu         sync.Mutex
+	start      time.Time
+	count      int
+	suppressed uint64
+}
+
+// rateLimiter allows a fixed number of messages per interval for each call site.
+type rateLimiter struct {
+	burst    int
+	interval time.Duration
+	sites    sync.Map // map[uintptr]*rateState
+}
+
+// allow reports whether a message from the call site is allowed, and if so,
+// the number of messages suppressed since the last allowed one.
+func (rl *rateLimiter) allow(pc uintptr, now time.Time) (bool, uint64) {
+	v, ok := rl.sites.Load(pc)
+	if !ok {
+		v, _ = rl.sites.LoadOrStore(pc, &rateState{start: now})
+	}
+	s := v.(*rateState)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.start) >= rl.interval {
+		s.start, s.count = now, 0
+	}
+	if s.count >= rl.burst {
+		s.suppressed++
+		return false, 0
+	}
+	s.count++
+	suppressed := s.suppressed
+	s.suppressed = 0
+	return true, suppressed
+}
+
+// SetRateLimit limits each call site to at most burst messages per interval.
+// The number of suppressed messages is attached as the "suppressed" attribute
+// to the next message allowed from the same call site.
+// A burst of 0 or less disables rate limiting.
+func (l *Logger) SetRateLimit(burst int, interval time.Duration) {
+	if burst <= 0 {
+		l.rateLimit.Store(nil)
+		return
+	}
+	l.rateLimit.Store(&rateLimiter{burst: burst, interval: interval})
+}
+
+// dedupFlushDelay is the time after the first repeat at which the repeat count is written.
+const dedupFlushDelay = time.Second
+
+// deduper collapses identical consecutive messages.
+type deduper struct {
+	c       *core
+	mu      sync.Mutex
+	key     []byte
+	last    *message
+	repeats uint64
+	timer   *time.Timer // writes the pending repeat count
+}
+
+func dedupKey(b []byte, m *message) []byte {
+	b = append(b, byte(m.level))
+	b = binary.LittleEndian.AppendUint64(b, uint64(m.pc))
+	b = append(b, m.text...)
+	return appendAttrs(b, m.attrs)
+}
+
+func (d *deduper) write(c *core, m *message) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if m.writeExtra != nil {
+		/* messages with payload are never collapsed */
+		err := d.flush(c, m.timestamp)
+		d.last, d.key = nil, nil
+		if werr := c.emit(m); err == nil {
+			err = werr
+		}
+		return err
+	}
+	s := snapshot(m)
+	key := dedupKey(nil, s)
+	if d.last != nil && bytes.Equal(key, d.key) {
+		if d.repeats++; d.repeats == 1 {
+			d.schedule()
+		}
+		return nil
+	}
+	err := d.flush(c, m.timestamp)
+	d.last, d.key = s, key
+	if werr := c.emit(s); err == nil {
+		err = werr
+	}
+	return err
+}
+
+// schedule writes the repeat count after dedupFlushDelay in case no other message follows.
+func (d *deduper) schedule() {
+	if d.timer == nil {
+		d.timer = time.AfterFunc(dedupFlushDelay, func() {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			_ = d.flush(d.c, time.Now())
+		})
+		return
+	}
+	d.timer.Reset(dedupFlushDelay)
+}
+
+// flush writes the last message again with the repeat count, if it was repeated.
+func (d *deduper) flush(c *core, now time.Time) error {
+	if d.repeats == 0 {
+		return nil
+	}
+	m := *d.last
+	m.timestamp = now
+	m.attrs = append(m.attrs[:len(m.attrs):len(m.attrs)], Uint64("repeated", d.repeats))
+	d.repeats = 0
+	return c.emit(&m)
+}
+
+// SetDedup enables or disables collapsing identical consecutive messages from the same call site.
+// The first message is written immediately, while the repeats are counted and reported
+// with the "repeated" attribute when a different message is logged, on Flush,
+// or one second after the first repeat, whichever comes first.
+func (l *Logger) SetDedup(enabled bool) {
+	var d *deduper
+	if enabled {
+		d = &deduper{c: l.core}
+	}
+	if old := l.dedup.Swap(d); old != nil {
+		old.mu.Lock()
+		if old.timer != nil {
+			old.timer.Stop()
+		}
+		_ = old.flush(l.core, time.Now())
+		old.mu.Unlock()
+	}
+}
@@ -0,0 +1,124 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetRateLimit(2, 50*time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		logger.Warningf("close: %d", i)
+	}
+	logger.Warning("other call site")
+	output := buf.String()
+	if lines := strings.Count(output, "\n"); lines != 3 {
+		t.Errorf("expected 3 lines, got %d: %s", lines, output)
+	}
+	if strings.Contains(output, "close: 2") {
+		t.Errorf("expected message to be suppressed, got: %s", output)
+	}
+
+	logger.SetRateLimit(0, 0)
+	buf.Reset()
+	for i := 0; i < 5; i++ {
+		logger.Warningf("close: %d", i)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 5 {
+		t.Errorf("expected 5 lines without rate limit, got %d", lines)
+	}
+}
+
+func TestRateLimitSuppressedCount(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetRateLimit(1, 50*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 4; j++ {
+			logger.Warningf("close: %d", j)
+		}
+		if i == 0 {
+			time.Sleep(60 * time.Millisecond)
+		}
+	}
+	output := buf.String()
+	if lines := strings.Count(output, "\n"); lines != 2 {
+		t.Errorf("expected 2 lines, got %d: %s", lines, output)
+	}
+	if !strings.Contains(output, "close: 0 suppressed=3\n") {
+		t.Errorf("expected suppressed count, got: %s", output)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetDedup(true)
+
+	for i := 0; i < 4; i++ {
+		logger.Infow("rejected", slog.String("peer", "1.2.3.4"))
+	}
+	for i := 0; i < 2; i++ {
+		logger.Infow("rejected", slog.String("peer", "5.6.7.8"))
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	expected := []string{
+		"rejected peer=1.2.3.4",
+		"rejected peer=1.2.3.4 repeated=3",
+		"rejected peer=5.6.7.8",
+		"rejected peer=5.6.7.8 repeated=1",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d: %s", len(expected), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, expected[i]) {
+			t.Errorf("line %d: expected suffix %q, got %q", i, expected[i], line)
+		}
+	}
+}
+
+func TestDedupTimeout(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+	logger.SetDedup(true)
+	defer logger.SetDedup(false)
+
+	for i := 0; i < 3; i++ {
+		logger.Info("storm")
+	}
+	/* the repeat count is written without Flush or another message */
+	deadline := time.Now().Add(5 * time.Second)
+	for len(c.Records()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("the repeat count was not written: %v", c)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	r := c.Records()[1]
+	if n, ok := r.Attr("repeated"); !ok || n != uint64(2) {
+		t.Errorf("unexpected repeat count: %v", n)
+	}
+}
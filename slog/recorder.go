@@ -0,0 +1,104 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"io"
+	"sync"
+)
+
+// Recorder keeps the most recent messages in memory, and writes them to another
+// output on demand or when a severe message is recorded.
+//
+// To record messages below the level of the other outputs, add the recorder
+// with AddOutput and raise the logger level, for example:
+//
+//	rec, err := slog.NewRecorder(1000, slog.LevelError, slog.OutputWriter, os.Stderr)
+//	logger.SetLevel(slog.LevelDebug)
+//	logger.AddOutput(slog.LevelNotice, slog.FlagNone, slog.OutputAuto, os.Stderr)
+//	logger.AddOutput(slog.LevelDebug, slog.FlagNone, slog.OutputRecorder, rec)
+type Recorder struct {
+	mu      sync.Mutex
+	ring    []*message
+	next    int
+	count   int
+	trigger Level
+	target  output
+}
+
+// NewRecorder returns a Recorder keeping the last size messages. When a message
+// at the trigger level or more severe is recorded, all recorded messages are written
+// to the target output and the recorder is cleared. LevelSilence disables the trigger.
+func NewRecorder(size int, trigger Level, t OutputType, v ...any) (*Recorder, error) {
+	target, err := newOutput(t, v...)
+	if err != nil {
+		return nil, err
+	}
+	if size < 1 {
+		size = 1
+	}
+	return &Recorder{
+		ring:    make([]*message, size),
+		trigger: trigger,
+		target:  target,
+	}, nil
+}
+
+func (r *Recorder) record(m *message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ring[r.next] = snapshot(m)
+	r.next = (r.next + 1) % len(r.ring)
+	if r.count < len(r.ring) {
+		r.count++
+	}
+	if m.level != LevelSilence && m.level <= r.trigger {
+		return r.dump()
+	}
+	return nil
+}
+
+// Len returns the number of recorded messages.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// Dump writes all recorded messages to the target output, oldest first, and clears the recorder.
+func (r *Recorder) Dump() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dump()
+}
+
+func (r *Recorder) dump() error {
+	var err error
+	start := (r.next - r.count + len(r.ring)) % len(r.ring)
+	for i := 0; i < r.count; i++ {
+		j := (start + i) % len(r.ring)
+		if werr := r.target.WriteMsg(r.ring[j]); err == nil {
+			err = werr
+		}
+		r.ring[j] = nil
+	}
+	r.count = 0
+	return err
+}
+
+// Close closes the target output.
+func (r *Recorder) Close() error {
+	if c, ok := r.target.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+type recorderWriter struct {
+	r *Recorder
+}
+
+func (w *recorderWriter) WriteMsg(m *message) error {
+	return w.r.record(m)
+}
@@ -0,0 +1,64 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestRecorder(t *testing.T) {
+	var term, dump bytes.Buffer
+	rec, err := slog.NewRecorder(3, slog.LevelError, slog.OutputWriter, &dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelDebug)
+	logger.AddOutput(slog.LevelNotice, slog.FlagNone, slog.OutputWriter, &term)
+	logger.AddOutput(slog.LevelDebug, slog.FlagNone, slog.OutputRecorder, rec)
+
+	for i := 1; i <= 5; i++ {
+		logger.Debugf("context %d", i)
+	}
+	if term.Len() != 0 || dump.Len() != 0 {
+		t.Fatalf("expected no output yet, got %q and %q", term.String(), dump.String())
+	}
+	if rec.Len() != 3 {
+		t.Errorf("expected 3 recorded messages, got %d", rec.Len())
+	}
+
+	logger.Error("broken")
+	lines := strings.Split(strings.TrimSuffix(dump.String(), "\n"), "\n")
+	expected := []string{"context 4", "context 5", "broken"}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d dumped lines, got %q", len(expected), dump.String())
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, expected[i]) {
+			t.Errorf("line %d: expected suffix %q, got %q", i, expected[i], line)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "D ") {
+		t.Errorf("expected original level, got %q", lines[0])
+	}
+	if output := term.String(); strings.Contains(output, "context") || !strings.Contains(output, "broken") {
+		t.Errorf("unexpected terminal output: %q", output)
+	}
+	if rec.Len() != 0 {
+		t.Errorf("expected recorder to be cleared, got %d", rec.Len())
+	}
+
+	dump.Reset()
+	logger.Debug("on demand")
+	if err := rec.Dump(); err != nil {
+		t.Fatal(err)
+	}
+	if output := dump.String(); !strings.HasSuffix(output, "on demand\n") {
+		t.Errorf("unexpected dump: %q", output)
+	}
+}
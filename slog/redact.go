@@ -0,0 +1,143 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// RedactMask replaces redacted values.
+const RedactMask = "[REDACTED]"
+
+// DefaultRedactKeys are the attribute keys redacted when RedactConfig.Keys is nil.
+var DefaultRedactKeys = []string{
+	"password", "passwd", "secret", "token", "authorization", "cookie",
+	"api_key", "apikey", "private_key", "psk",
+}
+
+// RedactConfig specifies what a redactor masks.
+type RedactConfig struct {
+	// Keys are attribute keys whose values are replaced with RedactMask. Keys match
+	// case-insensitively as substrings, e.g. "token" matches "Access-Token".
+	// nil means DefaultRedactKeys.
+	Keys []string
+	// Patterns are replaced with RedactMask in messages, attribute values and extra payloads.
+	// If a pattern has a submatch, only the first submatch is replaced, e.g. `password=(\S+)`.
+	Patterns []*regexp.Regexp
+	// Bytes are byte sequences, e.g. pre-shared keys, replaced with RedactMask like Patterns.
+	// In binary data, the bytes are masked in place and dumped as "**" so that the offsets are unchanged.
+	Bytes [][]byte
+}
+
+type redactor struct {
+	keys     []string
+	patterns []*regexp.Regexp
+	bytes    [][]byte
+}
+
+// NewRedactor returns a Hook masking secrets in messages as specified by cfg,
+// which may be nil for the defaults.
+func NewRedactor(cfg *RedactConfig) Hook {
+	r := &redactor{}
+	keys := DefaultRedactKeys
+	if cfg != nil {
+		if cfg.Keys != nil {
+			keys = cfg.Keys
+		}
+		r.patterns = append(r.patterns, cfg.Patterns...)
+		for _, b := range cfg.Bytes {
+			if len(b) > 0 {
+				r.bytes = append(r.bytes, append([]byte(nil), b...))
+			}
+		}
+	}
+	for _, key := range keys {
+		r.keys = append(r.keys, strings.ToLower(key))
+	}
+	return r
+}
+
+func (r *redactor) matchKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range r.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact replaces all patterns in s, or the first submatch of each match if any.
+// The result is nil if nothing was replaced.
+func (r *redactor) redact(s []byte) []byte {
+	changed := false
+	for _, re := range r.patterns {
+		matches := re.FindAllSubmatchIndex(s, -1)
+		if matches == nil {
+			continue
+		}
+		var b []byte
+		last := 0
+		for _, m := range matches {
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			b = append(b, s[last:start]...)
+			b = append(b, RedactMask...)
+			last = end
+		}
+		s, changed = append(b, s[last:]...), true
+	}
+	for _, p := range r.bytes {
+		if bytes.Contains(s, p) {
+			s, changed = bytes.ReplaceAll(s, p, []byte(RedactMask)), true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s
+}
+
+func (r *redactor) Process(e *Entry) bool {
+	if redacted := r.redact(e.Message()); redacted != nil {
+		e.SetMessage(string(redacted))
+	}
+	attrs := e.Attrs()
+	for i, a := range attrs {
+		if r.matchKey(a.Key) {
+			attrs[i] = String(a.Key, RedactMask)
+			continue
+		}
+		if len(r.patterns) == 0 && len(r.bytes) == 0 || a.kind != KindString && a.kind != KindAny {
+			continue
+		}
+		if redacted := r.redact(a.AppendValue(nil)); redacted != nil {
+			attrs[i] = String(a.Key, string(redacted))
+		}
+	}
+	if len(r.patterns) == 0 && len(r.bytes) == 0 {
+		return true
+	}
+	if bin := e.Binary(); bin != nil {
+		for _, p := range r.bytes {
+			for i := bytes.Index(bin, p); i >= 0; {
+				e.MaskBinary(i, len(p))
+				next := bytes.Index(bin[i+len(p):], p)
+				if next < 0 {
+					break
+				}
+				i += len(p) + next
+			}
+		}
+	} else if extra, _ := e.Extra(); extra != nil {
+		if redacted := r.redact(extra); redacted != nil {
+			e.SetExtra(redacted)
+		}
+	}
+	return true
+}
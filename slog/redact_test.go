@@ -0,0 +1,83 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestRedactor(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+	logger.AddHook(slog.NewRedactor(&slog.RedactConfig{
+		Keys:     slog.DefaultRedactKeys,
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`(?i)bearer (\S+)`), regexp.MustCompile(`sk-[0-9a-f]{8}`)},
+		Bytes:    [][]byte{[]byte("hunter2")},
+	}))
+
+	logger.Infow("login",
+		slog.String("user", "alice"),
+		slog.String("Password", "hunter2"),
+		slog.String("X-Access-Token", "abc"),
+		slog.String("header", "Bearer abc.def"),
+		slog.Err(errors.New("invalid key sk-0123abcd")),
+		slog.Int("attempt", 1),
+	)
+	logger.Println(0, slog.LevelInfo, func(w io.Writer) error {
+		_, err := io.WriteString(w, "psk=hunter2\n")
+		return err
+	}, "config", "loaded with hunter2")
+
+	records := c.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	for key, want := range map[string]any{
+		"user":           "alice",
+		"Password":       slog.RedactMask,
+		"X-Access-Token": slog.RedactMask,
+		"header":         "Bearer " + slog.RedactMask,
+		"error":          "invalid key " + slog.RedactMask,
+		"attempt":        int64(1),
+	} {
+		if v, _ := records[0].Attr(key); v != want {
+			t.Errorf("attribute %s: expected %v, got %v", key, want, v)
+		}
+	}
+	if r := records[1]; r.Message != "config loaded with "+slog.RedactMask || string(r.Extra) != "psk="+slog.RedactMask+"\n" {
+		t.Errorf("unexpected record: %+v", r)
+	}
+}
+
+func TestRedactorBinary(t *testing.T) {
+	origLevel := slog.Default().Level()
+	defer slog.Default().SetLevel(origLevel)
+	c := slog.Default().CaptureOutput(t)
+	slog.Default().SetLevel(slog.LevelDebug)
+	slog.Default().AddHook(slog.NewRedactor(&slog.RedactConfig{Bytes: [][]byte{{0xde, 0xad, 0xbe, 0xef}}}))
+	defer slog.Default().SetHooks()
+
+	data := []byte{0x16, 0x03, 0xde, 0xad, 0xbe, 0xef, 0x2a, 0xde, 0xad, 0xbe, 0xef}
+	slog.Binary(slog.LevelDebug, data, "handshake")
+
+	r := c.AssertLogged(t, slog.LevelDebug, "handshake")
+	if want := []byte("\x16\x03\x00\x00\x00\x00*\x00\x00\x00\x00"); !bytes.Equal(r.Binary, want) {
+		t.Errorf("expected binary %q, got %q", want, r.Binary)
+	}
+	if dump := string(r.Extra); strings.Contains(dump, "DE AD BE EF") ||
+		!strings.Contains(dump, "16 03 ** ** ** ** 2A ** ** ** **") || !strings.Contains(dump, "..*********") {
+		t.Errorf("unexpected dump: %q", dump)
+	}
+	if data[2] != 0xde {
+		t.Error("the logged data was modified")
+	}
+}
@@ -0,0 +1,358 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateConfig specifies when and how a RotatingFile is rotated.
+type RotateConfig struct {
+	// MaxSize is the size in bytes at which the file is rotated, 0 means no limit.
+	MaxSize int64
+	// MaxAge is the age at which the file is rotated, 0 means no limit.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files to keep, 0 means keeping all of them.
+	MaxBackups int
+	// Compress enables gzip compression of rotated files.
+	Compress bool
+	// ReopenOnHangup reopens the file on SIGHUP, for compatibility with logrotate.
+	ReopenOnHangup bool
+}
+
+// backupLayout is appended to the file path when the file is rotated.
+const backupLayout = "20060102-150405.000"
+
+// maxBackupTries limits the search for an unused backup path.
+const maxBackupTries = 1000
+
+// maxPendingSize limits the buffered data, which is dropped if the file can not be written.
+const maxPendingSize = 64 * 1024
+
+// RotatingFile is a log file that rotates by size and/or age.
+// Written data is buffered until Flush so that a message is never split across files.
+type RotatingFile struct {
+	path string
+	cfg  RotateConfig
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64
+	opened time.Time
+	buf    []byte
+	closed bool
+
+	bgMu    sync.Mutex
+	bg      sync.WaitGroup
+	stopSig func()
+}
+
+// OpenRotatingFile opens or creates the file at path for appending.
+// A nil cfg disables rotation.
+func OpenRotatingFile(path string, cfg *RotateConfig) (*RotatingFile, error) {
+	f := &RotatingFile{path: path}
+	if cfg != nil {
+		f.cfg = *cfg
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	if f.cfg.ReopenOnHangup {
+		f.stopSig = notifyHangup(f)
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.f, f.size, f.opened = file, info.Size(), time.Now()
+	return nil
+}
+
+func (f *RotatingFile) closeFile() error {
+	if f.f == nil {
+		return nil
+	}
+	err := f.f.Close()
+	f.f = nil
+	return err
+}
+
+// Write buffers p until the next Flush.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	f.buf = append(f.buf, p...)
+	if len(f.buf) > maxPendingSize {
+		if err := f.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes buffered data to the file, rotating it first if necessary.
+func (f *RotatingFile) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	return f.flush()
+}
+
+func (f *RotatingFile) flush() error {
+	if len(f.buf) == 0 {
+		return nil
+	}
+	if f.f == nil {
+		if err := f.open(); err != nil {
+			f.dropPending()
+			return err
+		}
+	}
+	var rotateErr error
+	if f.shouldRotate(len(f.buf)) {
+		/* on failure, keep writing to the current file if it is still open */
+		if rotateErr = f.rotate(); f.f == nil {
+			f.dropPending()
+			return rotateErr
+		}
+	}
+	n, err := f.f.Write(f.buf)
+	f.size += int64(n)
+	f.buf = f.buf[:0]
+	if err == nil {
+		err = rotateErr
+	}
+	return err
+}
+
+// dropPending discards the buffered data if it exceeds maxPendingSize.
+func (f *RotatingFile) dropPending() {
+	if len(f.buf) > maxPendingSize {
+		f.buf = nil
+	}
+}
+
+func (f *RotatingFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.cfg.MaxSize > 0 && f.size+int64(n) > f.cfg.MaxSize {
+		return true
+	}
+	return f.cfg.MaxAge > 0 && time.Since(f.opened) >= f.cfg.MaxAge
+}
+
+// Rotate renames the current file to a backup and starts a new one.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	if err := f.flush(); err != nil {
+		return err
+	}
+	return f.rotate()
+}
+
+func (f *RotatingFile) rotate() error {
+	backup, err := f.backupName(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := f.closeFile(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.path, backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.bg.Add(1)
+	go func() {
+		defer f.bg.Done()
+		f.bgMu.Lock()
+		defer f.bgMu.Unlock()
+		if f.cfg.Compress {
+			_ = compressFile(backup)
+		}
+		_ = f.prune()
+	}()
+	return nil
+}
+
+// backupName returns an unused backup path, advancing t on collisions.
+func (f *RotatingFile) backupName(t time.Time) (string, error) {
+	for i := 0; i < maxBackupTries; i++ {
+		name := f.path + "." + t.Format(backupLayout)
+		used, err := pathExists(name)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			if used, err = pathExists(name + ".gz"); err != nil {
+				return "", err
+			}
+		}
+		if !used {
+			return name, nil
+		}
+		t = t.Add(time.Millisecond)
+	}
+	return "", fmt.Errorf("slog: no unused backup name for %q", f.path)
+}
+
+func pathExists(name string) (bool, error) {
+	_, err := os.Lstat(name)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// Reopen closes and reopens the file at the configured path,
+// so that a file renamed by an external tool is released.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	if err := f.flush(); err != nil {
+		return err
+	}
+	if err := f.closeFile(); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+// Close flushes and closes the file, waiting for pending compression.
+// Calling Close again has no effect.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	err := f.flush()
+	if cerr := f.closeFile(); err == nil {
+		err = cerr
+	}
+	f.mu.Unlock()
+	if f.stopSig != nil {
+		f.stopSig()
+	}
+	f.bg.Wait()
+	return err
+}
+
+// Backups returns the paths of rotated files, oldest first.
+func (f *RotatingFile) Backups() ([]string, error) {
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	backups := matches[:0]
+	for _, name := range matches {
+		suffix := strings.TrimSuffix(name[len(f.path)+1:], ".gz")
+		if _, err := time.Parse(backupLayout, suffix); err == nil {
+			backups = append(backups, name)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return strings.TrimSuffix(backups[i], ".gz") < strings.TrimSuffix(backups[j], ".gz")
+	})
+	return backups, nil
+}
+
+func (f *RotatingFile) prune() error {
+	if f.cfg.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := f.Backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > f.cfg.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
+
+type fileWriter struct {
+	textWriter
+	f *RotatingFile
+}
+
+func newFileWriter(path string, cfg *RotateConfig) (output, error) {
+	f, err := OpenRotatingFile(path, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &fileWriter{textWriter{out: f}, f}, nil
+}
+
+func (w *fileWriter) reconnect() error {
+	return w.f.Reopen()
+}
+
+func (w *fileWriter) Close() error {
+	return w.f.Close()
+}
@@ -0,0 +1,10 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build !unix
+
+package slog
+
+func notifyHangup(*RotatingFile) (stop func()) {
+	return nil
+}
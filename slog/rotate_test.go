@@ -0,0 +1,294 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	f, err := slog.OpenRotatingFile(path, &slog.RotateConfig{MaxSize: 100, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := strings.Repeat("x", 59) + "\n"
+	for i := 0; i < 5; i++ {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	backups, err := f.Backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Errorf("expected 2 backups, got %v", backups)
+	}
+	for _, name := range append(backups, path) {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != line {
+			t.Errorf("%s: expected a single line, got %q", name, b)
+		}
+	}
+}
+
+func TestRotatingFileCompress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	f, err := slog.OpenRotatingFile(path, &slog.RotateConfig{Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("rotated\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	backups, err := f.Backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 || !strings.HasSuffix(backups[0], ".gz") {
+		t.Fatalf("expected 1 compressed backup, got %v", backups)
+	}
+	zf, err := os.Open(backups[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zf.Close()
+	zr, err := gzip.NewReader(zf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "rotated\n" {
+		t.Errorf("unexpected backup content: %q", b)
+	}
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	f, err := slog.OpenRotatingFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte("old\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// simulate logrotate
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("new\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "new\n" {
+		t.Errorf("unexpected content after reopen: %q", b)
+	}
+}
+
+func TestOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	logger.SetOutput(slog.OutputFile, path, &slog.RotateConfig{MaxSize: 1024})
+	logger.Info("to file", "payload")
+	logger.SetOutput(slog.OutputDiscard) // closes the file
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "to file") || !strings.Contains(string(b), "payload") {
+		t.Errorf("unexpected file content: %q", b)
+	}
+}
+
+func TestRotatingFileCollision(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	f, err := slog.OpenRotatingFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for i := 0; i < 3; i++ {
+		if _, err := f.Write([]byte{'0' + byte(i), '\n'}); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backups, err := f.Backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("expected 3 backups, got %v", backups)
+	}
+	for i, name := range backups {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := string([]byte{'0' + byte(i), '\n'}); string(b) != want {
+			t.Errorf("%s: expected %q, got %q", name, want, b)
+		}
+	}
+}
+
+func TestRotatingFileBackupNameError(t *testing.T) {
+	/* the backup suffix makes the name too long for most file systems */
+	path := filepath.Join(t.TempDir(), strings.Repeat("x", 240))
+	f, err := slog.OpenRotatingFile(path, &slog.RotateConfig{MaxSize: 10})
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+	done := make(chan error, 1)
+	go func() {
+		if _, err := f.Write([]byte("first line\n")); err != nil {
+			done <- err
+			return
+		}
+		if err := f.Flush(); err != nil {
+			done <- err
+			return
+		}
+		_, _ = f.Write([]byte("second line\n"))
+		done <- f.Flush()
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Skip("the file system accepts long names")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Flush did not return")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "first line\nsecond line\n" {
+		t.Errorf("unexpected content: %q", b)
+	}
+}
+
+func TestRotatingFileOpenError(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "test.log")
+	f, err := slog.OpenRotatingFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	/* the file can not be reopened while the directory is a regular file */
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reopen(); err == nil {
+		t.Fatal("expected an error")
+	}
+	line := []byte(strings.Repeat("x", 1023) + "\n")
+	for i := 0; i < 1000; i++ {
+		_, _ = f.Write(line)
+	}
+	if err := f.Flush(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if err := os.Remove(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := info.Size(); size == 0 || size > 64*1024 {
+		t.Errorf("unexpected pending data: %d bytes", size)
+	}
+}
+
+func TestRotatingFileClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	f, err := slog.OpenRotatingFile(path, &slog.RotateConfig{ReopenOnHangup: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("closed\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("lost\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write: unexpected error %v", err)
+	}
+	for name, fn := range map[string]func() error{"Flush": f.Flush, "Rotate": f.Rotate, "Reopen": f.Reopen} {
+		if err := fn(); !errors.Is(err, os.ErrClosed) {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("the file was recreated after Close: %v", err)
+	}
+}
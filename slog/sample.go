@@ -0,0 +1,121 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides whether a message is kept. It must be safe for concurrent use.
+type Sampler interface {
+	Sample() bool
+}
+
+type everySampler struct {
+	n     uint64
+	count atomic.Uint64
+}
+
+func (s *everySampler) Sample() bool {
+	return (s.count.Add(1)-1)%s.n == 0
+}
+
+// SampleEvery returns a Sampler keeping the first and then every n-th message.
+func SampleEvery(n uint64) Sampler {
+	if n < 1 {
+		n = 1
+	}
+	return &everySampler{n: n}
+}
+
+type rateSampler float64
+
+func (s rateSampler) Sample() bool {
+	return rand.Float64() < float64(s)
+}
+
+// SampleRate returns a Sampler keeping each message with probability p.
+func SampleRate(p float64) Sampler {
+	return rateSampler(p)
+}
+
+type sampling struct {
+	samplers [LevelVeryVerbose + 1]Sampler
+	sampled  [LevelVeryVerbose + 1]atomic.Uint64
+	stop     chan struct{}
+}
+
+// sample reports whether a message at the level is kept.
+func (s *sampling) sample(level Level) bool {
+	if level < LevelSilence || level > LevelVeryVerbose {
+		return true
+	}
+	sampler := s.samplers[level]
+	if sampler == nil || sampler.Sample() {
+		return true
+	}
+	s.sampled[level].Add(1)
+	return false
+}
+
+// SetSampling samples messages at each level with the given samplers, keeping all messages
+// at levels without one. For example, SampleRate(0.01) for LevelVeryVerbose keeps about 1%.
+// If interval is positive, the numbers of sampled-out messages since the last report are written
+// every interval at LevelNotice, with one attribute per level. A nil map disables sampling.
+func (l *Logger) SetSampling(samplers map[Level]Sampler, interval time.Duration) {
+	var s *sampling
+	if len(samplers) > 0 {
+		s = &sampling{}
+		for level, sampler := range samplers {
+			if level >= LevelSilence && level <= LevelVeryVerbose {
+				s.samplers[level] = sampler
+			}
+		}
+		if interval > 0 {
+			s.stop = make(chan struct{})
+			go l.reportSampled(s, interval)
+		}
+	}
+	if old := l.sampling.Swap(s); old != nil && old.stop != nil {
+		close(old.stop)
+	}
+}
+
+func (l *Logger) reportSampled(s *sampling, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-t.C:
+			var attrs []Attr
+			for level := range s.sampled {
+				if n := s.sampled[level].Swap(0); n > 0 {
+					attrs = append(attrs, Uint64(levelName[level], n))
+				}
+			}
+			if len(attrs) == 0 || l.Level() < LevelNotice {
+				continue
+			}
+			_ = l.emit(&message{
+				timestamp: now,
+				level:     LevelNotice,
+				flags:     Flags(l.flags.Load()),
+				file:      "???",
+				resolved:  true,
+				text:      []byte("sampled out messages"),
+				attrs:     attrs,
+				format:    l.format.Load(),
+			})
+		}
+	}
+}
+
+// SampledOut returns the total number of messages discarded by sampling.
+func (l *Logger) SampledOut() uint64 {
+	return l.sampledOut.Load()
+}
@@ -0,0 +1,72 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestSampling(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelVeryVerbose)
+	c := logger.CaptureOutput(t)
+	logger.SetSampling(map[slog.Level]slog.Sampler{
+		slog.LevelDebug:       slog.SampleEvery(10),
+		slog.LevelVeryVerbose: slog.SampleRate(0),
+	}, 0)
+	defer logger.SetSampling(nil, 0)
+
+	for i := 0; i < 100; i++ {
+		logger.Debugf("debug %d", i)
+		logger.VeryVerbosef("trace %d", i)
+		logger.Warningf("warning %d", i)
+	}
+	counts := make(map[slog.Level]int)
+	for _, r := range c.Records() {
+		counts[r.Level]++
+	}
+	if counts[slog.LevelDebug] != 10 || counts[slog.LevelVeryVerbose] != 0 || counts[slog.LevelWarning] != 100 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+	c.AssertLogged(t, slog.LevelDebug, "debug 0")
+	c.AssertLogged(t, slog.LevelDebug, "debug 90")
+	if n := logger.SampledOut(); n != 190 {
+		t.Errorf("expected 190 sampled out messages, got %d", n)
+	}
+
+	logger.SetSampling(nil, 0)
+	c.Reset()
+	logger.VeryVerbose("kept")
+	c.AssertLogged(t, slog.LevelVeryVerbose, "kept")
+}
+
+func TestSamplingReport(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+	logger.SetSampling(map[slog.Level]slog.Sampler{
+		slog.LevelInfo: slog.SampleEvery(4),
+	}, 10*time.Millisecond)
+	defer logger.SetSampling(nil, 0)
+
+	for i := 0; i < 8; i++ {
+		logger.Info("hello")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if r, ok := c.Find(slog.LevelNotice, "sampled out"); ok {
+			if v, _ := r.Attr("info"); v != uint64(6) {
+				t.Errorf("unexpected report: %+v", r.Attrs)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no sampling report")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,16 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build !unix
+
+package slog
+
+// NotifyLevelSignals is a no-op on platforms without SIGUSR1 and SIGUSR2.
+func (l *Logger) NotifyLevelSignals() (stop func()) {
+	return func() {}
+}
+
+// NotifyStackDump is a no-op on platforms without SIGQUIT.
+func (l *Logger) NotifyStackDump(Level) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,68 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build unix
+
+package slog
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NotifyLevelSignals makes the logger more verbose by one level on SIGUSR1,
+// and less verbose by one level on SIGUSR2. Call the returned function to stop.
+func (l *Logger) NotifyLevelSignals() (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				level := l.Level()
+				switch {
+				case sig == syscall.SIGUSR1 && level < LevelVeryVerbose:
+					level++
+				case sig == syscall.SIGUSR2 && level > LevelSilence:
+					level--
+				}
+				l.SetLevel(level)
+				l.Log(0, LevelNotice, nil, "log level is set to "+level.String())
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+// NotifyStackDump writes the stack traces of all goroutines at the given level on SIGQUIT,
+// instead of the runtime dumping them to stderr and exiting. Call the returned function to stop.
+func (l *Logger) NotifyStackDump(level Level) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, syscall.SIGQUIT)
+	go func() {
+		for {
+			select {
+			case <-ch:
+				stacks := allStacks()
+				l.Log(0, level, func(w io.Writer) error {
+					return writeIndented(w, stacks)
+				}, "SIGQUIT: goroutine stack dump")
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
@@ -0,0 +1,67 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build unix
+
+package slog_test
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func waitLevel(t *testing.T, logger *slog.Logger, level slog.Level) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for logger.Level() != level {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected level %v, got %v", level, logger.Level())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNotifyLevelSignals(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	stop := logger.NotifyLevelSignals()
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitLevel(t, logger, slog.LevelDebug)
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	waitLevel(t, logger, slog.LevelInfo)
+}
+
+func TestNotifyStackDump(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	c := logger.CaptureOutput(t)
+	stop := logger.NotifyStackDump(slog.LevelWarning)
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGQUIT); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if r, ok := c.Find(slog.LevelWarning, "stack dump"); ok {
+			if !strings.Contains(string(r.Extra), "TestNotifyStackDump") {
+				t.Errorf("expected all goroutine stacks, got: %s", r.Extra)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no stack dump")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
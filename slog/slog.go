@@ -25,6 +25,10 @@ var levelChar = [...]byte{
 	'-', 'F', 'E', 'W', 'I', 'I', 'D', 'V', 'V',
 }
 
+var levelName = [...]string{
+	"silence", "fatal", "error", "warning", "notice", "info", "debug", "verbose", "veryverbose",
+}
+
 var levelColor = [...]string{
 	";96", ";97;41", ";91", ";93", ";92", ";92", ";96", ";97", ";37",
 }
@@ -38,35 +42,32 @@ func Default() *Logger {
 
 // Printf is the low-level interface to write arbitary log messages.
 func Printf(calldepth int, level Level, extra func(io.Writer) error, format string, v ...any) error {
-	return std.output(calldepth+1, level, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, extra)
+	return std.output(calldepth+1, level, msgPrintf|msgUnleveled, format, v, extra, nil)
 }
 
 // Println is the low-level interface to write arbitary log messages.
 func Println(calldepth int, level Level, extra func(io.Writer) error, v ...any) error {
-	return std.output(calldepth+1, level, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, extra)
+	return std.output(calldepth+1, level, msgPrint|msgUnleveled, "", v, extra, nil)
+}
+
+// Log is the low-level interface to write log messages with attributes.
+func Log(calldepth int, level Level, extra func(io.Writer) error, msg string, attrs ...Attr) error {
+	return std.output(calldepth+1, level, msgString|msgUnleveled, msg, nil, extra, attrs)
 }
 
-// CheckLevel checks whether the given level is enabled.
+// CheckLevel checks whether the given level may be enabled for any call site.
 func CheckLevel(level Level) bool {
-	return level <= std.Level()
+	return std.CheckLevel(level)
 }
 
 // Temporaryf prints debug message regardless of log level.
 func Temporaryf(format string, v ...any) {
-	std.output(1, LevelSilence, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelSilence, msgPrintf, format, v, nil, nil)
 }
 
 // Temporary prints debug message regardless of log level.
 func Temporary(v ...any) {
-	std.output(1, LevelSilence, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelSilence, msgPrint, "", v, nil, nil)
 }
 
 // Fatalf logs serious problems that are likely to cause the program to exit.
@@ -74,9 +75,7 @@ func Fatalf(format string, v ...any) {
 	if !CheckLevel(LevelFatal) {
 		return
 	}
-	std.output(1, LevelFatal, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelFatal, msgPrintf, format, v, nil, nil)
 }
 
 // Fatal logs serious problems that are likely to cause the program to exit.
@@ -84,9 +83,15 @@ func Fatal(v ...any) {
 	if !CheckLevel(LevelFatal) {
 		return
 	}
-	std.output(1, LevelFatal, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelFatal, msgPrint, "", v, nil, nil)
+}
+
+// Fatalw logs serious problems that are likely to cause the program to exit, with attributes.
+func Fatalw(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelFatal) {
+		return
+	}
+	std.output(1, LevelFatal, msgString, msg, nil, nil, attrs)
 }
 
 // Errorf logs issues that shouldn't be ignored.
@@ -94,9 +99,7 @@ func Errorf(format string, v ...any) {
 	if !CheckLevel(LevelError) {
 		return
 	}
-	std.output(1, LevelError, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelError, msgPrintf, format, v, nil, nil)
 }
 
 // Error logs issues that shouldn't be ignored.
@@ -104,9 +107,15 @@ func Error(v ...any) {
 	if !CheckLevel(LevelError) {
 		return
 	}
-	std.output(1, LevelError, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelError, msgPrint, "", v, nil, nil)
+}
+
+// Errorw logs issues that shouldn't be ignored, with attributes.
+func Errorw(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelError) {
+		return
+	}
+	std.output(1, LevelError, msgString, msg, nil, nil, attrs)
 }
 
 // Warningf logs issues that may be ignored.
@@ -114,9 +123,7 @@ func Warningf(format string, v ...any) {
 	if !CheckLevel(LevelWarning) {
 		return
 	}
-	std.output(1, LevelWarning, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelWarning, msgPrintf, format, v, nil, nil)
 }
 
 // Warning logs issues that may be ignored.
@@ -124,9 +131,15 @@ func Warning(v ...any) {
 	if !CheckLevel(LevelWarning) {
 		return
 	}
-	std.output(1, LevelWarning, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelWarning, msgPrint, "", v, nil, nil)
+}
+
+// Warningw logs issues that may be ignored, with attributes.
+func Warningw(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelWarning) {
+		return
+	}
+	std.output(1, LevelWarning, msgString, msg, nil, nil, attrs)
 }
 
 // Noticef logs important status changes. The prefix is 'I'.
@@ -134,9 +147,7 @@ func Noticef(format string, v ...any) {
 	if !CheckLevel(LevelNotice) {
 		return
 	}
-	std.output(1, LevelNotice, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelNotice, msgPrintf, format, v, nil, nil)
 }
 
 // Notice logs important status changes. The prefix is 'I'.
@@ -144,9 +155,15 @@ func Notice(v ...any) {
 	if !CheckLevel(LevelNotice) {
 		return
 	}
-	std.output(1, LevelNotice, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelNotice, msgPrint, "", v, nil, nil)
+}
+
+// Noticew logs important status changes with attributes. The prefix is 'I'.
+func Noticew(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelNotice) {
+		return
+	}
+	std.output(1, LevelNotice, msgString, msg, nil, nil, attrs)
 }
 
 // Infof logs normal work reports.
@@ -154,9 +171,7 @@ func Infof(format string, v ...any) {
 	if !CheckLevel(LevelInfo) {
 		return
 	}
-	std.output(1, LevelInfo, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelInfo, msgPrintf, format, v, nil, nil)
 }
 
 // Info logs normal work reports.
@@ -164,9 +179,15 @@ func Info(v ...any) {
 	if !CheckLevel(LevelInfo) {
 		return
 	}
-	std.output(1, LevelInfo, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelInfo, msgPrint, "", v, nil, nil)
+}
+
+// Infow logs normal work reports, with attributes.
+func Infow(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelInfo) {
+		return
+	}
+	std.output(1, LevelInfo, msgString, msg, nil, nil, attrs)
 }
 
 // Debugf logs extra information for debugging.
@@ -174,9 +195,7 @@ func Debugf(format string, v ...any) {
 	if !CheckLevel(LevelDebug) {
 		return
 	}
-	std.output(1, LevelDebug, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelDebug, msgPrintf, format, v, nil, nil)
 }
 
 // Debug logs extra information for debugging.
@@ -184,9 +203,15 @@ func Debug(v ...any) {
 	if !CheckLevel(LevelDebug) {
 		return
 	}
-	std.output(1, LevelDebug, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelDebug, msgPrint, "", v, nil, nil)
+}
+
+// Debugw logs extra information for debugging, with attributes.
+func Debugw(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelDebug) {
+		return
+	}
+	std.output(1, LevelDebug, msgString, msg, nil, nil, attrs)
 }
 
 // Verbosef logs details for inspecting specific issues.
@@ -194,9 +219,7 @@ func Verbosef(format string, v ...any) {
 	if !CheckLevel(LevelVerbose) {
 		return
 	}
-	std.output(1, LevelVerbose, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelVerbose, msgPrintf, format, v, nil, nil)
 }
 
 // Verbose logs details for inspecting specific issues.
@@ -204,9 +227,15 @@ func Verbose(v ...any) {
 	if !CheckLevel(LevelVerbose) {
 		return
 	}
-	std.output(1, LevelVerbose, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelVerbose, msgPrint, "", v, nil, nil)
+}
+
+// Verbosew logs details for inspecting specific issues, with attributes.
+func Verbosew(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelVerbose) {
+		return
+	}
+	std.output(1, LevelVerbose, msgString, msg, nil, nil, attrs)
 }
 
 // VeryVerbosef logs more details that may significantly impact performance. The prefix is 'V'.
@@ -214,9 +243,7 @@ func VeryVerbosef(format string, v ...any) {
 	if !CheckLevel(LevelVeryVerbose) {
 		return
 	}
-	std.output(1, LevelVeryVerbose, func(b []byte) []byte {
-		return AppendMsgf(b, format, v...)
-	}, nil)
+	std.output(1, LevelVeryVerbose, msgPrintf, format, v, nil, nil)
 }
 
 // VeryVerbose logs more details that may significantly impact performance. The prefix is 'V'.
@@ -224,7 +251,13 @@ func VeryVerbose(v ...any) {
 	if !CheckLevel(LevelVeryVerbose) {
 		return
 	}
-	std.output(1, LevelVeryVerbose, func(b []byte) []byte {
-		return AppendMsg(b, v...)
-	}, nil)
+	std.output(1, LevelVeryVerbose, msgPrint, "", v, nil, nil)
+}
+
+// VeryVerbosew logs more details with attributes. The prefix is 'V'.
+func VeryVerbosew(msg string, attrs ...Attr) {
+	if !CheckLevel(LevelVeryVerbose) {
+		return
+	}
+	std.output(1, LevelVeryVerbose, msgString, msg, nil, nil, attrs)
 }
@@ -309,6 +309,45 @@ func TestWrap(t *testing.T) {
 	}
 }
 
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	parent := slog.NewLogger()
+	parent.SetOutput(slog.OutputWriter, &buf)
+	parent.SetLevel(slog.LevelInfo)
+
+	child := parent.With(slog.String("session", "abc")).WithPrefix("[abc] ")
+	grandchild := child.With(slog.Int("stream", 1))
+
+	child.Infof("hello %d", 1)
+	if output := buf.String(); !strings.Contains(output, "[abc] hello 1 session=abc\n") {
+		t.Errorf("expected child output to contain prefix and attribute, got: %s", output)
+	}
+
+	buf.Reset()
+	grandchild.Infow("data", slog.Int("len", 5))
+	if output := buf.String(); !strings.Contains(output, "[abc] data session=abc stream=1 len=5\n") {
+		t.Errorf("expected inherited attributes, got: %s", output)
+	}
+
+	buf.Reset()
+	parent.Info("plain")
+	if output := buf.String(); strings.Contains(output, "session=") || strings.Contains(output, "[abc]") {
+		t.Errorf("expected parent output without child context, got: %s", output)
+	}
+
+	// level is shared between parent and children
+	buf.Reset()
+	grandchild.SetLevel(slog.LevelWarning)
+	parent.Info("filtered")
+	child.Info("filtered")
+	if buf.Len() != 0 {
+		t.Errorf("expected no output after shared level change, got: %s", buf.String())
+	}
+	if parent.Level() != slog.LevelWarning {
+		t.Errorf("expected parent level %d, got %d", slog.LevelWarning, parent.Level())
+	}
+}
+
 func TestPackageLevelFunctions(t *testing.T) {
 	// Save current state
 	origLevel := slog.Default().Level()
@@ -495,6 +534,38 @@ func TestCheckf(t *testing.T) {
 	slog.Checkf(false, "should panic %d", 42)
 }
 
+func panicky() {
+	panic("boom")
+}
+
+func TestRecover(t *testing.T) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelError)
+	c := logger.CaptureOutput(t)
+
+	func() {
+		defer logger.Recover(slog.PanicContinue)
+		panicky()
+	}()
+	r := c.AssertLogged(t, slog.LevelFatal, "panic: boom")
+	if !strings.HasSuffix(r.File, "slog_test.go") {
+		t.Errorf("expected the panicking function as the source, got %s:%d", r.File, r.Line)
+	}
+	if !strings.Contains(string(r.Extra), "slog_test.panicky") {
+		t.Errorf("expected stack trace of the panic, got: %s", r.Extra)
+	}
+
+	c.Reset()
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Errorf("expected repanic with the same value, got %v", v)
+		}
+		c.AssertLogged(t, slog.LevelFatal, "panic: boom")
+	}()
+	defer logger.Recover(slog.PanicRepanic)
+	panicky()
+}
+
 func TestOutputf(t *testing.T) {
 	var buf bytes.Buffer
 	slog.Default().SetOutput(slog.OutputWriter, &buf)
@@ -550,3 +621,79 @@ func BenchmarkConcurrent(b *testing.B) {
 		}
 	})
 }
+
+func newBenchLogger(t slog.OutputType) *slog.Logger {
+	logger := slog.NewLogger()
+	logger.SetOutput(t, io.Discard)
+	logger.SetLevel(slog.LevelInfo)
+	return logger
+}
+
+func BenchmarkDisabled(b *testing.B) {
+	logger := newBenchLogger(slog.OutputWriter)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Debugw("request", slog.String("method", "GET"), slog.Int("status", i))
+	}
+}
+
+func BenchmarkText(b *testing.B) {
+	logger := newBenchLogger(slog.OutputWriter)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request served")
+	}
+}
+
+func BenchmarkTextAttrs(b *testing.B) {
+	logger := newBenchLogger(slog.OutputWriter).With(slog.String("session", "abc"))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infow("request", slog.String("method", "GET"), slog.Int("status", i))
+	}
+}
+
+func BenchmarkTextPrintf(b *testing.B) {
+	logger := newBenchLogger(slog.OutputWriter)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infof("request %s served", "GET")
+	}
+}
+
+func BenchmarkTextNoCaller(b *testing.B) {
+	logger := newBenchLogger(slog.OutputWriter)
+	if err := logger.SetFormat("%l %t %m%a"); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infow("request", slog.Int("status", i))
+	}
+}
+
+func BenchmarkTerminal(b *testing.B) {
+	logger := newBenchLogger(slog.OutputTerminal)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infow("request", slog.Int("status", i))
+	}
+}
+
+func BenchmarkJSON(b *testing.B) {
+	logger := newBenchLogger(slog.OutputJSON)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infow("request", slog.String("method", "GET"), slog.Int("status", i))
+	}
+}
+
+func BenchmarkParallel(b *testing.B) {
+	logger := newBenchLogger(slog.OutputWriter)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.Infow("request", slog.Int("status", 200))
+		}
+	})
+}
@@ -0,0 +1,112 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import "io"
+
+type teeOutput struct {
+	out      output
+	level    Level
+	flags    Flags
+	ownFlags bool
+	primary  bool // filtered by the logger level instead
+}
+
+// teeWriter writes each message to several outputs, each with its own level and flags.
+type teeWriter struct {
+	outs []teeOutput
+}
+
+func (w *teeWriter) WriteMsg(m *message) error {
+	var err error
+	flags := m.flags
+	for _, o := range w.outs {
+		if o.primary && m.secondary || !o.primary && m.level > o.level {
+			continue
+		}
+		m.flags = flags
+		if o.ownFlags {
+			m.flags = o.flags
+		}
+		if werr := o.out.WriteMsg(m); err == nil {
+			err = werr
+		}
+	}
+	m.flags = flags
+	return err
+}
+
+func (w *teeWriter) reconnect() error {
+	var err error
+	for _, o := range w.outs {
+		if r, ok := o.out.(reconnecter); ok {
+			if rerr := r.reconnect(); err == nil {
+				err = rerr
+			}
+		}
+	}
+	return err
+}
+
+func (w *teeWriter) flush() error {
+	var err error
+	for _, o := range w.outs {
+		if f, ok := o.out.(flusher); ok {
+			if ferr := f.flush(); err == nil {
+				err = ferr
+			}
+		}
+	}
+	return err
+}
+
+func (w *teeWriter) Close() error {
+	var err error
+	for _, o := range w.outs {
+		if c, ok := o.out.(io.Closer); ok {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+	}
+	return err
+}
+
+// AddOutput adds an output with its own minimum level and flags, keeping the existing outputs.
+// The added output receives messages up to its level regardless of the logger level,
+// while the existing output is still filtered by the logger level and module levels.
+// On error, the existing outputs are kept.
+func (l *Logger) AddOutput(level Level, flags Flags, t OutputType, v ...any) error {
+	w, err := newOutput(t, v...)
+	if err != nil {
+		return err
+	}
+	o := teeOutput{
+		out:      w,
+		level:    level,
+		flags:    flags,
+		ownFlags: true,
+	}
+	l.outMu.Lock()
+	outLevel := level
+	switch out := l.out.(type) {
+	case *discardWriter:
+		l.out = &teeWriter{outs: []teeOutput{o}}
+	case *teeWriter:
+		out.outs = append(out.outs, o)
+		for _, o := range out.outs {
+			if !o.primary && o.level > outLevel {
+				outLevel = o.level
+			}
+		}
+	default:
+		l.out = &teeWriter{outs: []teeOutput{
+			{out: out, primary: true},
+			o,
+		}}
+	}
+	l.setOutLevel(outLevel)
+	l.outMu.Unlock()
+	return nil
+}
@@ -0,0 +1,107 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestAddOutput(t *testing.T) {
+	var term, file bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelVeryVerbose)
+	logger.SetFlags(slog.FlagNanos)
+	logger.AddOutput(slog.LevelInfo, slog.FlagNone, slog.OutputTerminal, &term)
+	logger.AddOutput(slog.LevelVeryVerbose, slog.FlagUTC, slog.OutputWriter, &file)
+
+	logger.Info("both")
+	logger.VeryVerbose("file only")
+
+	if output := term.String(); !strings.Contains(output, "both") || strings.Contains(output, "file only") {
+		t.Errorf("unexpected terminal output: %q", output)
+	}
+	output := file.String()
+	if !strings.Contains(output, "both") || !strings.Contains(output, "file only") {
+		t.Errorf("unexpected file output: %q", output)
+	}
+	if strings.Count(output, "Z ") != 2 {
+		t.Errorf("expected UTC timestamps from output flags, got: %q", output)
+	}
+}
+
+func TestAddOutputKeepsPrimary(t *testing.T) {
+	var primary, extra bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelDebug)
+	logger.SetOutput(slog.OutputWriter, &primary)
+	logger.AddOutput(slog.LevelWarning, slog.FlagNone, slog.OutputWriter, &extra)
+
+	logger.Debug("debug")
+	logger.Error("error")
+
+	if output := primary.String(); !strings.Contains(output, "debug") || !strings.Contains(output, "error") {
+		t.Errorf("unexpected primary output: %q", output)
+	}
+	if output := extra.String(); strings.Contains(output, "debug") || !strings.Contains(output, "error") {
+		t.Errorf("unexpected extra output: %q", output)
+	}
+
+	// SetOutput replaces all outputs
+	primary.Reset()
+	extra.Reset()
+	logger.SetOutput(slog.OutputWriter, &primary)
+	logger.Error("replaced")
+	if extra.Len() != 0 {
+		t.Errorf("expected no output after replacement, got: %q", extra.String())
+	}
+}
+
+func TestAddOutputLevels(t *testing.T) {
+	var term, file bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	logger.SetOutput(slog.OutputWriter, &term)
+	logger.AddOutput(slog.LevelVeryVerbose, slog.FlagNone, slog.OutputWriter, &file)
+
+	if logger.Level() != slog.LevelInfo || !logger.CheckLevel(slog.LevelVeryVerbose) {
+		t.Errorf("unexpected levels: %v", logger.Level())
+	}
+	logger.Info("both")
+	logger.VeryVerbose("file only")
+	logger.SetLevel(slog.LevelDebug)
+	logger.Debug("debug both")
+
+	if output := term.String(); !strings.Contains(output, "both") || !strings.Contains(output, "debug both") || strings.Contains(output, "file only") {
+		t.Errorf("unexpected terminal output: %q", output)
+	}
+	if output := file.String(); strings.Count(output, "\n") != 3 {
+		t.Errorf("unexpected file output: %q", output)
+	}
+
+	// module levels apply to the existing output only
+	term.Reset()
+	file.Reset()
+	if err := logger.SetModuleLevels("slog/tee_test=verbose"); err != nil {
+		t.Fatal(err)
+	}
+	logger.Verbose("verbose both")
+	logger.VeryVerbose("file only")
+	if output := term.String(); !strings.Contains(output, "verbose both") || strings.Contains(output, "file only") {
+		t.Errorf("unexpected terminal output: %q", output)
+	}
+	if output := file.String(); strings.Count(output, "\n") != 2 {
+		t.Errorf("unexpected file output: %q", output)
+	}
+	_ = logger.SetModuleLevels("")
+
+	// replacing the outputs restores the logger level
+	logger.SetOutput(slog.OutputWriter, &term)
+	if logger.CheckLevel(slog.LevelVerbose) {
+		t.Error("expected verbose messages to be disabled after SetOutput")
+	}
+}
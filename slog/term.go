@@ -0,0 +1,33 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"io"
+	"os"
+)
+
+// ColorEnabled reports whether colored output should be written to w.
+// A non-empty NO_COLOR disables colors, then a FORCE_COLOR other than "" or "0"
+// enables them. Otherwise colors are used if w is a terminal and TERM is not "dumb".
+func ColorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); force != "" && force != "0" {
+		return true
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(interface{ Fd() uintptr })
+	return ok && isTerminal(f.Fd())
+}
+
+func newAutoWriter(out io.Writer) output {
+	if ColorEnabled(out) {
+		return newTermWriter(out)
+	}
+	return newTextWriter(out)
+}
@@ -0,0 +1,10 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+//go:build !linux
+
+package slog
+
+func isTerminal(uintptr) bool {
+	return false
+}
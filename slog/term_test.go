@@ -0,0 +1,76 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if slog.ColorEnabled(f) || slog.ColorEnabled(&bytes.Buffer{}) {
+		t.Error("expected no colors for a file or a buffer")
+	}
+
+	if runtime.GOOS == "linux" {
+		pty, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+		if err != nil {
+			t.Logf("skipping terminal check: %v", err)
+		} else {
+			defer pty.Close()
+			if !slog.ColorEnabled(pty) {
+				t.Error("expected colors for a terminal")
+			}
+			t.Setenv("TERM", "dumb")
+			if slog.ColorEnabled(pty) {
+				t.Error("expected no colors for TERM=dumb")
+			}
+		}
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+	if !slog.ColorEnabled(f) {
+		t.Error("expected colors with FORCE_COLOR")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if slog.ColorEnabled(f) {
+		t.Error("expected NO_COLOR to take precedence")
+	}
+}
+
+func TestOutputAuto(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	logger.SetOutput(slog.OutputAuto, &buf)
+	logger.Info("plain")
+	if output := buf.String(); strings.Contains(output, "\x1b[") {
+		t.Errorf("expected no escape codes, got %q", output)
+	}
+
+	buf.Reset()
+	t.Setenv("FORCE_COLOR", "1")
+	logger.SetOutput(slog.OutputAuto, &buf)
+	logger.Info("colored")
+	if output := buf.String(); !strings.HasPrefix(output, "\x1b[") {
+		t.Errorf("expected escape codes, got %q", output)
+	}
+}
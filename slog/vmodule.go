@@ -0,0 +1,128 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+type modulePattern struct {
+	pattern string
+	depth   int
+	level   Level
+}
+
+// vmodule holds per-module level overrides and caches the result for each call site.
+type vmodule struct {
+	patterns []modulePattern
+	maxLevel Level
+	cache    sync.Map // map[uintptr]Level, -1 if no pattern matches
+}
+
+func parseVModule(spec string) (*vmodule, error) {
+	vm := &vmodule{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		pattern, name, ok := strings.Cut(item, "=")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("slog: invalid module level %q", item)
+		}
+		pattern = strings.TrimSuffix(pattern, ".go")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("slog: invalid module pattern %q: %w", pattern, err)
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, err
+		}
+		vm.patterns = append(vm.patterns, modulePattern{
+			pattern: pattern,
+			depth:   strings.Count(pattern, "/") + 1,
+			level:   level,
+		})
+		if level > vm.maxLevel {
+			vm.maxLevel = level
+		}
+	}
+	if len(vm.patterns) == 0 {
+		return nil, nil
+	}
+	return vm, nil
+}
+
+// lastComponents returns the last n slash-separated components of s.
+func lastComponents(s string, n int) string {
+	i := len(s)
+	for ; n > 0; n-- {
+		i = strings.LastIndexByte(s[:i], '/')
+		if i < 0 {
+			return s
+		}
+	}
+	return s[i+1:]
+}
+
+// match returns the level of the first pattern matching either the
+// trailing components of the file path without extension, or those of its directory.
+func (vm *vmodule) match(file string) Level {
+	file = strings.TrimSuffix(file, ".go")
+	dir := path.Dir(file)
+	for _, p := range vm.patterns {
+		if ok, _ := path.Match(p.pattern, lastComponents(file, p.depth)); ok {
+			return p.level
+		}
+		if ok, _ := path.Match(p.pattern, lastComponents(dir, p.depth)); ok {
+			return p.level
+		}
+	}
+	return -1
+}
+
+// levelFor returns the module level for the call site, or -1 if no pattern matches.
+func (vm *vmodule) levelFor(pc uintptr) Level {
+	if level, ok := vm.cache.Load(pc); ok {
+		return level.(Level)
+	}
+	file, _ := resolveCaller(pc)
+	level := vm.match(file)
+	vm.cache.Store(pc, level)
+	return level
+}
+
+// SetModuleLevels sets per-module level overrides from a spec like
+// "net/hlistener=verbose,routines/*=debug". Each pattern is matched against the
+// trailing path components of the caller's source file (without ".go") or its
+// directory, and the first match decides the level for that call site.
+// Messages from other files are filtered by the logger level as usual, and those written
+// with Printf, Println and Log are filtered only at call sites matching a pattern.
+// An empty spec removes all overrides.
+func (l *Logger) SetModuleLevels(spec string) error {
+	vm, err := parseVModule(spec)
+	if err != nil {
+		return err
+	}
+	l.cfgMu.Lock()
+	defer l.cfgMu.Unlock()
+	l.vmodule.Store(vm)
+	l.updateMaxLevel()
+	return nil
+}
+
+// updateMaxLevel must be called with cfgMu held.
+func (c *core) updateMaxLevel() {
+	level := Level(c.level.Load())
+	if vm := c.vmodule.Load(); vm != nil && vm.maxLevel > level {
+		level = vm.maxLevel
+	}
+	if outLevel := Level(c.outLevel.Load()); outLevel > level {
+		level = outLevel
+	}
+	c.maxLevel.Store(int32(level))
+}
@@ -0,0 +1,110 @@
+// gosnippets (c) 2023-2026 He Xian <hexian000@outlook.com>
+// This code is licensed under MIT license (see LICENSE for details)
+
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hexian000/gosnippets/slog"
+)
+
+func TestSetModuleLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelInfo)
+	ctx := slog.NewContext(context.Background(), logger)
+
+	tests := []struct {
+		spec      string
+		level     slog.Level
+		shouldLog bool
+	}{
+		{"slog/vmodule_test=veryverbose", slog.LevelVeryVerbose, true},
+		{"vmodule_test.go=8", slog.LevelVeryVerbose, true},
+		{"*/slog=debug", slog.LevelDebug, true},
+		{"slog/*=debug", slog.LevelVerbose, false},
+		{"routines/*=veryverbose", slog.LevelDebug, false},
+		{"slog/vmodule_test=error,slog/*=veryverbose", slog.LevelWarning, false},
+		{"slog/vmodule_test=error", slog.LevelError, true},
+	}
+	for _, tt := range tests {
+		if err := logger.SetModuleLevels(tt.spec); err != nil {
+			t.Fatalf("%q: %v", tt.spec, err)
+		}
+		buf.Reset()
+		slog.LogContext(ctx, 0, tt.level, nil, "test")
+		if hasOutput := buf.Len() > 0; hasOutput != tt.shouldLog {
+			t.Errorf("%q: expected shouldLog=%v for level %d, got %v", tt.spec, tt.shouldLog, tt.level, hasOutput)
+		}
+	}
+}
+
+func TestModuleLevelsLowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelWarning)
+
+	for _, tt := range []struct {
+		spec      string
+		shouldLog bool
+	}{
+		{"", true},
+		{"routines/*=error", true},
+		{"slog/vmodule_test=error", false},
+		{"slog/vmodule_test=veryverbose", true},
+	} {
+		if err := logger.SetModuleLevels(tt.spec); err != nil {
+			t.Fatalf("%q: %v", tt.spec, err)
+		}
+		buf.Reset()
+		logger.Log(0, slog.LevelVerbose, nil, "test")
+		if hasOutput := buf.Len() > 0; hasOutput != tt.shouldLog {
+			t.Errorf("%q: expected shouldLog=%v, got %v", tt.spec, tt.shouldLog, hasOutput)
+		}
+	}
+}
+
+func TestModuleLevelsCheckLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.NewLogger()
+	logger.SetOutput(slog.OutputWriter, &buf)
+	logger.SetLevel(slog.LevelWarning)
+	if logger.CheckLevel(slog.LevelDebug) {
+		t.Error("Debug level should be disabled without module levels")
+	}
+	if err := logger.SetModuleLevels("slog/vmodule_test=debug"); err != nil {
+		t.Fatal(err)
+	}
+	if !logger.CheckLevel(slog.LevelDebug) {
+		t.Error("Debug level should be possibly enabled with module levels")
+	}
+	if logger.Level() != slog.LevelWarning {
+		t.Errorf("expected level %d, got %d", slog.LevelWarning, logger.Level())
+	}
+	logger.Debug("enabled here")
+	if !strings.Contains(buf.String(), "enabled here") {
+		t.Errorf("expected debug output, got: %q", buf.String())
+	}
+	for _, spec := range []string{"foo", "=debug", "foo=loud", "[=debug"} {
+		if err := logger.SetModuleLevels(spec); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
+
+func BenchmarkModuleLevelsFiltered(b *testing.B) {
+	logger := slog.NewLogger()
+	logger.SetLevel(slog.LevelInfo)
+	if err := logger.SetModuleLevels("routines/*=debug"); err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		logger.VeryVerbose("this should be filtered")
+	}
+}